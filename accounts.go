@@ -0,0 +1,165 @@
+package fortnox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Account data type
+type Account struct {
+	URL                            string   `json:"@url"`
+	Active                         bool     `json:"Active"`
+	BalanceBroughtForward          Floatish `json:"BalanceBroughtForward"`
+	BalanceCarriedForward          Floatish `json:"BalanceCarriedForward"`
+	CostCenter                     string   `json:"CostCenter"`
+	CostCenterSettings             string   `json:"CostCenterSettings"`
+	Description                    string   `json:"Description"`
+	Number                         int      `json:"Number"`
+	Project                        string   `json:"Project"`
+	ProjectSettings                string   `json:"ProjectSettings"`
+	SRU                            Intish   `json:"SRU"`
+	TransactionInformation         string   `json:"TransactionInformation"`
+	TransactionInformationSettings string   `json:"TransactionInformationSettings"`
+	VATCode                        string   `json:"VATCode"`
+	Year                           Intish   `json:"Year"`
+}
+
+// CreateAccount payload for creating accounts
+type CreateAccount struct {
+	Active                         *bool    `json:"Active,omitempty"`
+	BalanceBroughtForward          *float64 `json:"BalanceBroughtForward,omitempty"`
+	CostCenter                     *string  `json:"CostCenter,omitempty"`
+	CostCenterSettings             *string  `json:"CostCenterSettings,omitempty"`
+	Description                    *string  `json:"Description,omitempty"`
+	Number                         *int     `json:"Number,omitempty"`
+	Project                        *string  `json:"Project,omitempty"`
+	ProjectSettings                *string  `json:"ProjectSettings,omitempty"`
+	SRU                            *int     `json:"SRU,omitempty"`
+	TransactionInformation         *string  `json:"TransactionInformation,omitempty"`
+	TransactionInformationSettings *string  `json:"TransactionInformationSettings,omitempty"`
+	VATCode                        *string  `json:"VATCode,omitempty"`
+}
+
+// UpdateAccount payload for updating accounts
+type UpdateAccount CreateAccount
+
+// AccountQueryParams when searching accounts
+type AccountQueryParams struct {
+	SRU               int
+	FinancialYear     int
+	FinancialYearDate string
+	LastModified      time.Time
+	Page              int
+	Limit             int
+	Offset            int
+	Extra             map[string][]string
+}
+
+func (p AccountQueryParams) toValues() url.Values {
+
+	ret := make(url.Values)
+
+	if p.SRU > 0 {
+		ret["sru"] = []string{fmt.Sprintf("%d", p.SRU)}
+	}
+	if p.FinancialYear > 0 {
+		ret["financialyear"] = []string{fmt.Sprintf("%d", p.FinancialYear)}
+	}
+	if len(p.FinancialYearDate) > 0 {
+		ret["financialyeardate"] = []string{p.FinancialYearDate}
+	}
+	if !p.LastModified.IsZero() {
+		ret["lastmodified"] = []string{p.LastModified.Format(TimeFormat)}
+	}
+	if p.Limit > 0 {
+		ret["limit"] = []string{fmt.Sprintf("%d", p.Limit)}
+	}
+	if p.Offset > 0 {
+		ret["offset"] = []string{fmt.Sprintf("%d", p.Offset)}
+	}
+	if p.Page > 0 {
+		ret["page"] = []string{fmt.Sprintf("%d", p.Page)}
+	}
+	for k, vs := range p.Extra {
+		ret[k] = vs
+	}
+	return ret
+}
+
+// ListAccountsResp Response when listing accounts
+type ListAccountsResp struct {
+	Accounts        []*Account       `json:"Accounts"`
+	MetaInformation *MetaInformation `json:"MetaInformation"`
+}
+
+// ListAccounts lists the chart of accounts
+func (c *Client) ListAccounts(ctx context.Context, p *AccountQueryParams) (*ListAccountsResp, error) {
+	resp := &ListAccountsResp{}
+
+	var vals url.Values
+	if p != nil {
+		vals = p.toValues()
+	}
+
+	err := c.request(ctx, "GET", "accounts", nil, vals, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// AccountResp Response for single account
+type AccountResp struct {
+	Account Account `json:"Account"`
+}
+
+// GetAccount gets one account by number. financialYear is the id of the financial year, 0 for the current one
+func (c *Client) GetAccount(ctx context.Context, number int, financialYear int) (*Account, error) {
+
+	resp := &AccountResp{}
+
+	var vals url.Values
+	if financialYear > 0 {
+		vals = url.Values{"financialyear": {fmt.Sprintf("%d", financialYear)}}
+	}
+
+	err := c.request(ctx, "GET", "accounts/"+strconv.Itoa(number), nil, vals, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Account, nil
+}
+
+// CreateAccount creates an account
+func (c *Client) CreateAccount(ctx context.Context, account *CreateAccount) (*Account, error) {
+	resp := &AccountResp{}
+	err := c.request(ctx, "POST", "accounts/", &struct {
+		Account *CreateAccount `json:"Account"`
+	}{
+		Account: account,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Account, nil
+}
+
+// UpdateAccount updates an account
+func (c *Client) UpdateAccount(ctx context.Context, number int, account *UpdateAccount) (*Account, error) {
+	resp := &AccountResp{}
+	err := c.request(ctx, "PUT", "accounts/"+strconv.Itoa(number), &struct {
+		Account *UpdateAccount `json:"Account"`
+	}{
+		Account: account,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Account, nil
+}
@@ -0,0 +1,74 @@
+package fortnox
+
+import (
+	"context"
+	"testing"
+)
+
+func TestClient_Accounts(t *testing.T) {
+	api := newStubAPI(t, map[string]string{
+		"GET accounts":       `{"Accounts":[{"Number":1930,"Description":"Företagskonto","SRU":"7281","Year":"2","Active":true}],"MetaInformation":{"@CurrentPage":1,"@TotalPages":1,"@TotalResources":1}}`,
+		"GET accounts/1930":  `{"Account":{"Number":1930,"Description":"Företagskonto","BalanceBroughtForward":"1000.50","Year":"2"}}`,
+		"POST accounts/":     `{"Account":{"Number":1931,"Description":"Sparkonto"}}`,
+		"PUT accounts/1931":  `{"Account":{"Number":1931,"Description":"Sparkonto","Active":false}}`,
+		"GET financialyears": `{"FinancialYears":[{"Id":2,"FromDate":"2020-01-01","ToDate":"2020-12-31","AccountingMethod":"ACCRUAL"}],"MetaInformation":{"@CurrentPage":1,"@TotalPages":1,"@TotalResources":1}}`,
+	})
+	defer api.Close()
+	c := api.client()
+	ctx := context.Background()
+
+	list, err := c.ListAccounts(ctx, &AccountQueryParams{SRU: 7281, FinancialYear: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Accounts) != 1 || list.Accounts[0].Number != 1930 || list.Accounts[0].SRU != 7281 {
+		t.Fatalf("unexpected accounts %+v", list.Accounts)
+	}
+	if q := api.received("GET accounts")[0].Query; q.Get("sru") != "7281" || q.Get("financialyear") != "2" {
+		t.Fatal("unexpected query", q)
+	}
+
+	acc, err := c.GetAccount(ctx, 1930, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acc.BalanceBroughtForward != 1000.5 {
+		t.Fatal("unexpected balance", acc.BalanceBroughtForward)
+	}
+	if q := api.received("GET accounts/1930")[0].Query; q.Get("financialyear") != "2" {
+		t.Fatal("unexpected query", q)
+	}
+
+	number, desc, active := 1931, "Sparkonto", false
+	if _, err := c.CreateAccount(ctx, &CreateAccount{Number: &number, Description: &desc}); err != nil {
+		t.Fatal(err)
+	}
+	if acc, err = c.UpdateAccount(ctx, number, &UpdateAccount{Active: &active}); err != nil || acc.Active {
+		t.Fatal("unexpected account", acc, err)
+	}
+	if body := api.received("PUT accounts/1931")[0].Body; body != `{"Account":{"Active":false}}`+"\n" {
+		t.Fatal("unexpected body", body)
+	}
+
+	year, err := c.GetFinancialYearByDate(ctx, "2020-06-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if year.ID != 2 || year.FromDate.String() != "2020-01-01" {
+		t.Fatalf("unexpected financial year %+v", year)
+	}
+	if q := api.received("GET financialyears")[0].Query; q.Get("date") != "2020-06-01" {
+		t.Fatal("unexpected query", q)
+	}
+}
+
+func TestClient_GetFinancialYearByDateNotFound(t *testing.T) {
+	api := newStubAPI(t, map[string]string{
+		"GET financialyears": `{"FinancialYears":[],"MetaInformation":{"@CurrentPage":1,"@TotalPages":1,"@TotalResources":0}}`,
+	})
+	defer api.Close()
+
+	if _, err := api.client().GetFinancialYearByDate(context.Background(), "1999-01-01"); !IsNotFound(err) {
+		t.Fatal("expected not found, got", err)
+	}
+}
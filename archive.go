@@ -0,0 +1,110 @@
+package fortnox
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// ArchiveFile data type
+type ArchiveFile struct {
+	URL      string `json:"@url"`
+	Comments string `json:"Comments"`
+	ID       string `json:"Id"`
+	Name     string `json:"Name"`
+	Path     string `json:"Path"`
+	Size     Intish `json:"Size"`
+}
+
+// ArchiveFolder data type. Files and Folders are only set for the requested folder, not its subfolders
+type ArchiveFolder struct {
+	URL     string           `json:"@url"`
+	Email   string           `json:"Email"`
+	Files   []*ArchiveFile   `json:"Files"`
+	Folders []*ArchiveFolder `json:"Folders"`
+	ID      string           `json:"Id"`
+	Name    string           `json:"Name"`
+}
+
+// ArchiveFolderResp Response for single folder
+type ArchiveFolderResp struct {
+	Folder ArchiveFolder `json:"Folder"`
+}
+
+// ArchiveFileResp Response for single file
+type ArchiveFileResp struct {
+	File ArchiveFile `json:"File"`
+}
+
+func pathValues(path string) url.Values {
+	if path == "" {
+		return nil
+	}
+	return url.Values{"path": {path}}
+}
+
+// GetArchiveFolder gets a folder of the archive by path, e.g. "Kvitton/2018". An empty path gets the root folder
+func (c *Client) GetArchiveFolder(ctx context.Context, path string) (*ArchiveFolder, error) {
+
+	resp := &ArchiveFolderResp{}
+
+	err := c.request(ctx, "GET", "archive", nil, pathValues(path), resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Folder, nil
+}
+
+// CreateArchiveFolder creates a folder in the parent folder, an empty parent is the root folder
+func (c *Client) CreateArchiveFolder(ctx context.Context, parent string, name string) (*ArchiveFolder, error) {
+
+	resp := &ArchiveFolderResp{}
+
+	req := &struct {
+		Folder struct {
+			Name string `json:"Name"`
+		} `json:"Folder"`
+	}{}
+	req.Folder.Name = name
+	err := c.request(ctx, "POST", "archive", req, pathValues(parent), resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Folder, nil
+}
+
+// DeleteArchiveFolder deletes a folder of the archive by path, including its files.
+// An empty path would delete the root folder, so it's refused with an error matching ErrValidation
+func (c *Client) DeleteArchiveFolder(ctx context.Context, path string) error {
+	if trimmed := strings.Trim(path, "/ "); trimmed == "" || strings.EqualFold(trimmed, "root") {
+		return errors.Wrap(ErrValidation, "refusing to delete the archive root folder")
+	}
+	return c.request(ctx, "DELETE", "archive", nil, pathValues(path), nil)
+}
+
+// UploadArchiveFile uploads a file to a folder of the archive, an empty folder is the root folder
+func (c *Client) UploadArchiveFile(ctx context.Context, folder string, filename string, r io.Reader) (*ArchiveFile, error) {
+
+	resp := &ArchiveFileResp{}
+
+	err := c.upload(ctx, "archive", pathValues(folder), filename, r, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.File, nil
+}
+
+// DownloadArchiveFile streams the content of a file in the archive. The caller must close it
+func (c *Client) DownloadArchiveFile(ctx context.Context, id string) (*Document, error) {
+	return c.getDocumentStream(ctx, "archive/"+url.PathEscape(id), nil, id, false)
+}
+
+// DeleteArchiveFile deletes a file in the archive
+func (c *Client) DeleteArchiveFile(ctx context.Context, id string) error {
+	return c.deleteResource(ctx, "archive/"+url.PathEscape(id))
+}
@@ -0,0 +1,115 @@
+package fortnox
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_UploadArchiveFile(t *testing.T) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the body must be sent again when retrying
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(429)
+			return
+		}
+		if r.Method != "POST" || r.URL.Path != "/archive" || r.URL.Query().Get("path") != "Kvitton" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		f, header, err := r.FormFile("file")
+		if err != nil {
+			t.Error(err)
+			w.WriteHeader(400)
+			return
+		}
+		data, _ := ioutil.ReadAll(f)
+		if header.Filename != "receipt.txt" || string(data) != "hello" {
+			t.Errorf("unexpected file %s: %q", header.Filename, data)
+		}
+		w.WriteHeader(201)
+		fmt.Fprint(w, `{"File":{"Id":"abc","Name":"receipt.txt","Path":"Kvitton","Size":5}}`)
+	}))
+	defer ts.Close()
+
+	c := NewClient(WithURLOpts(ts.URL+"/"), WithRateLimitOpts(0, 0, 0), WithRetryOpts(RetryPolicy{MaxAttempts: 2, RetryPOST: true, MaxBackoff: time.Millisecond}))
+	f, err := c.UploadArchiveFile(context.Background(), "Kvitton", "receipt.txt", strings.NewReader("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.ID != "abc" || f.Size.Int() != 5 {
+		t.Fatalf("unexpected file %+v", f)
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Fatal("expected 2 requests, got", n)
+	}
+}
+
+func TestClient_DownloadArchiveFile(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/archive/abc" {
+			w.WriteHeader(404)
+			fmt.Fprint(w, `{"ErrorInformation":{"Error":1,"Message":"Kunde inte hitta filen.","Code":2000000}}`)
+			return
+		}
+		if r.Header.Get("Accept") != "*/*" {
+			t.Errorf("unexpected accept %s", r.Header.Get("Accept"))
+		}
+		w.Header().Set("Content-Type", "application/pdf")
+		fmt.Fprint(w, strings.Repeat("x", 100000))
+	}))
+	defer ts.Close()
+
+	c := NewClient(WithURLOpts(ts.URL+"/"), WithRateLimitOpts(0, 0, 0))
+	doc, err := c.DownloadArchiveFile(context.Background(), "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(doc)
+	doc.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 100000 {
+		t.Fatal("unexpected length", len(data))
+	}
+	if doc.ContentType != "application/pdf" || doc.Filename != "abc" {
+		t.Fatalf("unexpected document %s %s", doc.ContentType, doc.Filename)
+	}
+
+	if _, err := c.DownloadArchiveFile(context.Background(), "missing"); !IsNotFound(err) {
+		t.Fatal("expected not found, got", err)
+	}
+}
+
+func TestClient_DeleteArchiveFolder(t *testing.T) {
+	api := newStubAPI(t, map[string]string{
+		"DELETE archive": ``,
+	})
+	defer api.Close()
+	c := api.client()
+	ctx := context.Background()
+
+	for _, path := range []string{"", "/", "root"} {
+		if err := c.DeleteArchiveFolder(ctx, path); !IsValidation(err) {
+			t.Errorf("expected deleting %q to be refused, got %v", path, err)
+		}
+	}
+	if n := len(api.received("DELETE archive")); n != 0 {
+		t.Fatal("expected no requests, got", n)
+	}
+
+	if err := c.DeleteArchiveFolder(ctx, "Kvitton/2018"); err != nil {
+		t.Fatal(err)
+	}
+	if q := api.received("DELETE archive")[0].Query; q.Get("path") != "Kvitton/2018" {
+		t.Fatal("unexpected query", q)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"time"
 )
 
 // Article data type
@@ -99,6 +100,7 @@ type ArticleQueryParams struct {
 	Manufacturer              string
 	ManufacturerArticleNumber string
 	SupplierName              string
+	LastModified              time.Time
 	Page                      int
 	Limit                     int
 	Offset                    int
@@ -127,6 +129,9 @@ func (p ArticleQueryParams) toValues() url.Values {
 	if len(p.SupplierName) > 0 {
 		ret["suppliername"] = []string{p.SupplierName}
 	}
+	if !p.LastModified.IsZero() {
+		ret["lastmodified"] = []string{p.LastModified.Format(TimeFormat)}
+	}
 	if p.Limit > 0 {
 		ret["limit"] = []string{fmt.Sprintf("%d", p.Limit)}
 	}
@@ -210,3 +215,50 @@ func (c *Client) UpdateArticle(ctx context.Context, artNum string, article *Upda
 func (c *Client) DeleteArticle(ctx context.Context, artNum string) error {
 	return c.deleteResource(ctx, "articles/"+artNum)
 }
+
+// ArticleIterator walks through every page of articles
+type ArticleIterator struct {
+	it *pageIterator
+}
+
+// Articles iterates over all articles matching the params, fetching pages as needed
+func (c *Client) Articles(ctx context.Context, p *ArticleQueryParams, opts ...IteratorOption) *ArticleIterator {
+	params := ArticleQueryParams{}
+	if p != nil {
+		params = *p
+	}
+	return &ArticleIterator{it: newPageIterator(ctx, params.Page, func(ctx context.Context, page int) ([]interface{}, *MetaInformation, error) {
+		pageParams := params
+		pageParams.Page = page
+		resp, err := c.ListArticles(ctx, &pageParams)
+		if err != nil {
+			return nil, nil, err
+		}
+		items := make([]interface{}, len(resp.Articles))
+		for i, o := range resp.Articles {
+			items[i] = o
+		}
+		return items, resp.MetaInformation, nil
+	}, opts)}
+}
+
+// Next advances to the next article, returning false when done or on error
+func (i *ArticleIterator) Next() bool {
+	return i.it.next()
+}
+
+// Article is the current article
+func (i *ArticleIterator) Article() *Article {
+	v, _ := i.it.cur.(*Article)
+	return v
+}
+
+// Err is the error which stopped iteration, if any
+func (i *ArticleIterator) Err() error {
+	return i.it.err
+}
+
+// Close stops iteration early
+func (i *ArticleIterator) Close() {
+	i.it.close()
+}
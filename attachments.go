@@ -0,0 +1,122 @@
+package fortnox
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"io"
+	"net/url"
+	"strconv"
+)
+
+// attachmentsResource is the newer attachments api, which lives outside of /3/
+const attachmentsResource = "/api/fileattachments/attachments-v1"
+
+// Attachment entity types
+const (
+	AttachmentEntityOffer   = "OF"
+	AttachmentEntityOrder   = "O"
+	AttachmentEntityInvoice = "F"
+)
+
+// AttachmentEntity references the document a file is attached to, e.g. {AttachmentEntityInvoice, 1001}
+type AttachmentEntity struct {
+	Type string
+	ID   int
+}
+
+// Attachment links a file in the archive or inbox to an offer, order or invoice
+type Attachment struct {
+	ID         string `json:"id"`
+	EntityID   int    `json:"entityId"`
+	EntityType string `json:"entityType"`
+	FileID     string `json:"fileId"`
+	// IncludeOnSend sends the file along with the document when it's emailed
+	IncludeOnSend bool   `json:"includeOnSend"`
+	Name          string `json:"name"`
+	Size          int64  `json:"size"`
+}
+
+// ListAttachments lists the files attached to an entity
+func (c *Client) ListAttachments(ctx context.Context, entity AttachmentEntity) ([]*Attachment, error) {
+	var resp []*Attachment
+
+	vals := url.Values{
+		"entityid":   {strconv.Itoa(entity.ID)},
+		"entitytype": {entity.Type},
+	}
+	err := c.request(ctx, "GET", attachmentsResource, nil, vals, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CreateAttachment attaches a file in the archive or inbox to an entity
+func (c *Client) CreateAttachment(ctx context.Context, entity AttachmentEntity, fileID string, includeOnSend bool) (*Attachment, error) {
+	var resp []*Attachment
+
+	type attach struct {
+		EntityID      int    `json:"entityId"`
+		EntityType    string `json:"entityType"`
+		FileID        string `json:"fileId"`
+		IncludeOnSend bool   `json:"includeOnSend"`
+	}
+	req := []attach{{
+		EntityID:      entity.ID,
+		EntityType:    entity.Type,
+		FileID:        fileID,
+		IncludeOnSend: includeOnSend,
+	}}
+	err := c.request(ctx, "POST", attachmentsResource, req, nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+	if len(resp) == 0 {
+		return nil, errors.New("no attachment in response")
+	}
+	return resp[0], nil
+}
+
+// DeleteAttachment removes an attachment, the file stays in the archive
+func (c *Client) DeleteAttachment(ctx context.Context, id string) error {
+	return c.deleteResource(ctx, attachmentsResource+"/"+url.PathEscape(id))
+}
+
+// AttachFile uploads a file to the entity's inbox folder and attaches it to the entity
+func (c *Client) AttachFile(ctx context.Context, entity AttachmentEntity, filename string, r io.Reader, includeOnSend bool) (*Attachment, error) {
+	f, err := c.UploadInboxFile(ctx, attachmentInbox(entity.Type), filename, r)
+	if err != nil {
+		return nil, err
+	}
+	a, err := c.CreateAttachment(ctx, entity, f.ID, includeOnSend)
+	if err != nil {
+		return nil, discardFile(ctx, c.DeleteInboxFile, f.ID, err)
+	}
+	return a, nil
+}
+
+// AttachFileToInvoice uploads a file and attaches it to an invoice
+func (c *Client) AttachFileToInvoice(ctx context.Context, id int, filename string, r io.Reader, includeOnSend bool) (*Attachment, error) {
+	return c.AttachFile(ctx, AttachmentEntity{Type: AttachmentEntityInvoice, ID: id}, filename, r, includeOnSend)
+}
+
+// AttachFileToOrder uploads a file and attaches it to an order
+func (c *Client) AttachFileToOrder(ctx context.Context, id int, filename string, r io.Reader, includeOnSend bool) (*Attachment, error) {
+	return c.AttachFile(ctx, AttachmentEntity{Type: AttachmentEntityOrder, ID: id}, filename, r, includeOnSend)
+}
+
+// AttachFileToOffer uploads a file and attaches it to an offer
+func (c *Client) AttachFileToOffer(ctx context.Context, id int, filename string, r io.Reader, includeOnSend bool) (*Attachment, error) {
+	return c.AttachFile(ctx, AttachmentEntity{Type: AttachmentEntityOffer, ID: id}, filename, r, includeOnSend)
+}
+
+func attachmentInbox(entityType string) string {
+	switch entityType {
+	case AttachmentEntityOffer:
+		return InboxOffers
+	case AttachmentEntityOrder:
+		return InboxOrders
+	default:
+		return InboxCustomerInvoices
+	}
+}
@@ -0,0 +1,84 @@
+package fortnox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_AttachFileUploadsAndLinks(t *testing.T) {
+	var deleted []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/3/inbox":
+			if r.URL.Query().Get("path") != InboxCustomerInvoices {
+				t.Errorf("unexpected inbox folder %s", r.URL.Query().Get("path"))
+			}
+			w.WriteHeader(201)
+			fmt.Fprint(w, `{"File":{"Id":"file-1","Name":"note.pdf"}}`)
+		case r.Method == "POST" && r.URL.Path == "/api/fileattachments/attachments-v1":
+			var req []map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Error(err)
+				w.WriteHeader(400)
+				return
+			}
+			if len(req) != 1 || req[0]["entityId"] != 1001.0 || req[0]["entityType"] != "F" || req[0]["fileId"] != "file-1" {
+				t.Errorf("unexpected attachment request %v", req)
+			}
+			w.WriteHeader(201)
+			fmt.Fprint(w, `[{"id":"att-1","entityId":1001,"entityType":"F","fileId":"file-1","includeOnSend":true}]`)
+		case r.Method == "DELETE":
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(204)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(WithURLOpts(ts.URL+"/3/"), WithRateLimitOpts(0, 0, 0))
+	a, err := c.AttachFileToInvoice(context.Background(), 1001, "note.pdf", strings.NewReader("%PDF"), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.ID != "att-1" || !a.IncludeOnSend {
+		t.Fatalf("unexpected attachment %+v", a)
+	}
+	if len(deleted) != 0 {
+		t.Fatal("unexpected deletes", deleted)
+	}
+}
+
+func TestClient_AttachFileCleansUp(t *testing.T) {
+	var deleted []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/3/inbox":
+			w.WriteHeader(201)
+			fmt.Fprint(w, `{"File":{"Id":"file-1","Name":"receipt.pdf"}}`)
+		case r.Method == "POST" && r.URL.Path == "/3/supplierinvoicefileconnections/":
+			w.WriteHeader(404)
+			fmt.Fprint(w, `{"ErrorInformation":{"Error":1,"Message":"Kunde inte hitta leverantörsfakturan.","Code":2000000}}`)
+		case r.Method == "DELETE":
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(204)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(WithURLOpts(ts.URL+"/3/"), WithRateLimitOpts(0, 0, 0))
+	_, err := c.AttachFileToSupplierInvoice(context.Background(), 12, "receipt.pdf", strings.NewReader("%PDF"))
+	if !IsNotFound(err) {
+		t.Fatal("expected not found, got", err)
+	}
+	if len(deleted) != 1 || deleted[0] != "/3/inbox/file-1" {
+		t.Fatal("expected uploaded file to be deleted, got", deleted)
+	}
+}
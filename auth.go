@@ -0,0 +1,309 @@
+package fortnox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultAuthURL is the default OAuth2 authorization url
+	DefaultAuthURL = "https://apps.fortnox.se/oauth-v1/auth"
+	// DefaultTokenURL is the default OAuth2 token url
+	DefaultTokenURL = "https://apps.fortnox.se/oauth-v1/token"
+
+	mimeForm = "application/x-www-form-urlencoded"
+)
+
+var (
+	// tokens this close to expiry are refreshed before being used
+	tokenExpiryLeeway = 30 * time.Second
+	timeNow           = time.Now
+)
+
+// Token is an OAuth2 access token along with the refresh token used to rotate it
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	TokenType    string    `json:"token_type,omitempty"`
+	Scope        string    `json:"scope,omitempty"`
+	Expiry       time.Time `json:"expiry,omitempty"`
+}
+
+// Valid reports whether the access token is set and not about to expire
+func (t *Token) Valid() bool {
+	return t != nil && t.AccessToken != "" && !t.expired()
+}
+
+func (t *Token) expired() bool {
+	if t.Expiry.IsZero() {
+		return false
+	}
+	return !timeNow().Add(tokenExpiryLeeway).Before(t.Expiry)
+}
+
+// OAuthError is returned when the token endpoint rejects a request
+type OAuthError struct {
+	HTTPStatus  int
+	Code        string
+	Description string
+}
+
+// Error pretty print error
+func (o OAuthError) Error() string {
+	return fmt.Sprintf("%d - %s: %s", o.HTTPStatus, o.Code, o.Description)
+}
+
+// OAuthConfig describes an integration registered with fortnox
+type OAuthConfig struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+	// AuthURL defaults to DefaultAuthURL
+	AuthURL string
+	// TokenURL defaults to DefaultTokenURL
+	TokenURL   string
+	HTTPClient *http.Client
+}
+
+// AuthCodeURL builds the url the user should be sent to in order to authorize the integration.
+// state is passed back untouched to the redirect url and should be verified there.
+func (o *OAuthConfig) AuthCodeURL(state string) string {
+	authURL := o.AuthURL
+	if authURL == "" {
+		authURL = DefaultAuthURL
+	}
+
+	v := url.Values{
+		"client_id":     {o.ClientID},
+		"response_type": {"code"},
+		"access_type":   {"offline"},
+	}
+	if o.RedirectURL != "" {
+		v.Set("redirect_uri", o.RedirectURL)
+	}
+	if len(o.Scopes) > 0 {
+		v.Set("scope", strings.Join(o.Scopes, " "))
+	}
+	if state != "" {
+		v.Set("state", state)
+	}
+
+	if strings.Contains(authURL, "?") {
+		return authURL + "&" + v.Encode()
+	}
+	return authURL + "?" + v.Encode()
+}
+
+// Exchange an authorization code for a token. Careful, do this only once per auth code
+func (o *OAuthConfig) Exchange(ctx context.Context, code string) (*Token, error) {
+	v := url.Values{
+		"grant_type": {"authorization_code"},
+		"code":       {code},
+	}
+	if o.RedirectURL != "" {
+		v.Set("redirect_uri", o.RedirectURL)
+	}
+	return o.retrieveToken(ctx, v)
+}
+
+// Refresh gets a new token using a refresh token. Fortnox rotates refresh tokens, so the old one is invalid afterwards
+func (o *OAuthConfig) Refresh(ctx context.Context, refreshToken string) (*Token, error) {
+	if refreshToken == "" {
+		return nil, errors.New("no refresh token")
+	}
+	v := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	}
+	return o.retrieveToken(ctx, v)
+}
+
+func (o *OAuthConfig) retrieveToken(ctx context.Context, v url.Values) (*Token, error) {
+	tokenURL := o.TokenURL
+	if tokenURL == "" {
+		tokenURL = DefaultTokenURL
+	}
+	client := o.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: defaultTimeout}
+	}
+
+	req, err := http.NewRequest("POST", tokenURL, strings.NewReader(v.Encode()))
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request")
+	}
+	req = req.WithContext(ctx)
+	req.SetBasicAuth(o.ClientID, o.ClientSecret)
+	req.Header.Set("Content-Type", mimeForm)
+	req.Header.Set("Accept", mimeJSON)
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "error sending request")
+	}
+	defer resp.Body.Close()
+
+	result := &struct {
+		AccessToken      string `json:"access_token"`
+		RefreshToken     string `json:"refresh_token"`
+		TokenType        string `json:"token_type"`
+		Scope            string `json:"scope"`
+		ExpiresIn        int64  `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}{}
+
+	bodyPreview, _ := getRespBodyPreview(resp, 128)
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to decode %d token response [%s]", resp.StatusCode, bodyPreview))
+	}
+
+	if resp.StatusCode != http.StatusOK || result.AccessToken == "" {
+		return nil, OAuthError{HTTPStatus: resp.StatusCode, Code: result.Error, Description: result.ErrorDescription}
+	}
+
+	token := &Token{
+		AccessToken:  result.AccessToken,
+		RefreshToken: result.RefreshToken,
+		TokenType:    result.TokenType,
+		Scope:        result.Scope,
+	}
+	if result.ExpiresIn > 0 {
+		token.Expiry = timeNow().Add(time.Duration(result.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+// Token returns a copy of the client's current OAuth2 token, or nil when using a static access token.
+// Without a TokenStore, persist it after use since the refresh token may have been rotated.
+func (c *Client) Token() *Token {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+	if c.token == nil {
+		return nil
+	}
+	t := *c.token
+	return &t
+}
+
+func (c *Client) usesOAuth() bool {
+	return c.clientOptions.OAuth != nil
+}
+
+// validToken returns a usable token, refreshing it if it is about to expire.
+// If stale is given it has been rejected by fortnox and is replaced, unless another caller already did so.
+// If a refreshed token can't be saved to the store the error is returned, but the client keeps the token and saves it
+// before its next request, since fortnox has revoked the stored refresh token. Client.Token returns it meanwhile.
+func (c *Client) validToken(ctx context.Context, stale *Token) (*Token, error) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	store := c.clientOptions.TokenStore
+	if store != nil {
+		if c.unsaved {
+			if err := c.saveUnsaved(ctx); err != nil {
+				return nil, err
+			}
+		} else if err := c.loadToken(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.token == nil {
+		return nil, errors.New("no oauth token, exchange an authorization code first")
+	}
+
+	if c.usableToken(stale) {
+		t := *c.token
+		return &t, nil
+	}
+
+	if store != nil {
+		unlock, err := store.Lock(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to lock token store")
+		}
+		defer unlock()
+
+		// another client may have refreshed while we waited for the lock
+		if err := c.loadToken(ctx); err != nil {
+			return nil, err
+		}
+		if c.usableToken(stale) {
+			t := *c.token
+			return &t, nil
+		}
+	}
+
+	token, err := c.clientOptions.OAuth.Refresh(ctx, c.token.RefreshToken)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to refresh token")
+	}
+	c.token = token
+
+	if store != nil {
+		if err := store.Save(ctx, token); err != nil {
+			c.unsaved = true
+			return nil, errors.Wrap(err, "failed to save refreshed token")
+		}
+	}
+
+	t := *token
+	return &t, nil
+}
+
+// saveUnsaved saves a refreshed token which couldn't be saved before
+func (c *Client) saveUnsaved(ctx context.Context) error {
+	store := c.clientOptions.TokenStore
+	unlock, err := store.Lock(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to lock token store")
+	}
+	defer unlock()
+	if err := store.Save(ctx, c.token); err != nil {
+		return errors.Wrap(err, "failed to save refreshed token")
+	}
+	c.unsaved = false
+	return nil
+}
+
+func (c *Client) usableToken(stale *Token) bool {
+	return c.token.Valid() && (stale == nil || stale.AccessToken != c.token.AccessToken)
+}
+
+// loadToken replaces the current token with the stored one, seeding the store if it is empty
+func (c *Client) loadToken(ctx context.Context) error {
+	store := c.clientOptions.TokenStore
+	token, err := store.Load(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to load token")
+	}
+	if token != nil {
+		c.token = token
+		return nil
+	}
+	if c.token != nil {
+		return errors.Wrap(store.Save(ctx, c.token), "failed to save token")
+	}
+	return nil
+}
+
+func (c *Client) authHeaders(token *Token) map[string]string {
+	if token != nil {
+		return map[string]string{
+			"Authorization": fmt.Sprintf("Bearer %s", token.AccessToken),
+		}
+	}
+	return map[string]string{
+		"Authorization": fmt.Sprintf("Bearer %s", c.clientOptions.AccessToken),
+		"Client-Secret": c.clientOptions.ClientSecret,
+	}
+}
@@ -0,0 +1,133 @@
+package fortnox
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestOAuthConfig_AuthCodeURL(t *testing.T) {
+	conf := &OAuthConfig{
+		ClientID:    "id",
+		RedirectURL: "https://example.com/cb",
+		Scopes:      []string{"invoice", "order"},
+	}
+
+	u, err := url.Parse(conf.AuthCodeURL("xyz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	if q.Get("client_id") != "id" {
+		t.Fatal("unexpected client_id", q.Get("client_id"))
+	}
+	if q.Get("scope") != "invoice order" {
+		t.Fatal("unexpected scope", q.Get("scope"))
+	}
+	if q.Get("state") != "xyz" {
+		t.Fatal("unexpected state", q.Get("state"))
+	}
+	if q.Get("response_type") != "code" {
+		t.Fatal("unexpected response_type", q.Get("response_type"))
+	}
+}
+
+func newTestTokenServer(t *testing.T, refreshes *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id, secret, _ := r.BasicAuth(); id != "id" || secret != "secret" {
+			w.WriteHeader(401)
+			fmt.Fprint(w, `{"error":"invalid_client","error_description":"bad client"}`)
+			return
+		}
+		r.ParseForm()
+		switch r.Form.Get("grant_type") {
+		case "authorization_code":
+			fmt.Fprint(w, `{"access_token":"access0","refresh_token":"refresh0","expires_in":3600,"token_type":"bearer"}`)
+		case "refresh_token":
+			n := atomic.AddInt32(refreshes, 1)
+			if r.Form.Get("refresh_token") != fmt.Sprintf("refresh%d", n-1) {
+				w.WriteHeader(400)
+				fmt.Fprint(w, `{"error":"invalid_grant","error_description":"bad refresh token"}`)
+				return
+			}
+			fmt.Fprintf(w, `{"access_token":"access%d","refresh_token":"refresh%d","expires_in":3600,"token_type":"bearer"}`, n, n)
+		}
+	}))
+}
+
+func TestOAuthConfig_Exchange(t *testing.T) {
+	var refreshes int32
+	ts := newTestTokenServer(t, &refreshes)
+	defer ts.Close()
+
+	conf := &OAuthConfig{ClientID: "id", ClientSecret: "secret", TokenURL: ts.URL}
+	token, err := conf.Exchange(context.Background(), "code")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != "access0" || token.RefreshToken != "refresh0" {
+		t.Fatalf("unexpected token %+v", token)
+	}
+	if !token.Valid() {
+		t.Fatal("token should be valid")
+	}
+
+	conf.ClientSecret = "wrong"
+	_, err = conf.Exchange(context.Background(), "code")
+	if oErr, ok := err.(OAuthError); !ok || oErr.Code != "invalid_client" {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestClient_RefreshesToken(t *testing.T) {
+	var refreshes int32
+	ts := newTestTokenServer(t, &refreshes)
+	defer ts.Close()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != fmt.Sprintf("Bearer access%d", atomic.LoadInt32(&refreshes)) {
+			w.WriteHeader(401)
+			fmt.Fprint(w, `{"ErrorInformation":{"Error":1,"Message":"Invalid token","Code":2000311}}`)
+			return
+		}
+		fmt.Fprint(w, `{"Label":{"Id":1,"Description":"test"}}`)
+	}))
+	defer api.Close()
+
+	conf := &OAuthConfig{ClientID: "id", ClientSecret: "secret", TokenURL: ts.URL}
+	expired := &Token{AccessToken: "access0", RefreshToken: "refresh0", Expiry: time.Now().Add(-time.Minute)}
+	c := NewClient(WithOAuthOpts(conf, expired), WithURLOpts(api.URL+"/"))
+
+	// expired token is refreshed before the request
+	if _, err := c.CreateLabel(context.Background(), "test"); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&refreshes); n != 1 {
+		t.Fatal("expected 1 refresh, got", n)
+	}
+
+	// token rejected by api is refreshed and request retried
+	c.tokenMu.Lock()
+	c.token.Expiry = time.Now().Add(time.Hour)
+	c.tokenMu.Unlock()
+	api.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access2" {
+			w.WriteHeader(401)
+			fmt.Fprint(w, `{"ErrorInformation":{"Error":1,"Message":"Invalid token","Code":2000311}}`)
+			return
+		}
+		fmt.Fprint(w, `{"Label":{"Id":1,"Description":"test"}}`)
+	})
+	if _, err := c.CreateLabel(context.Background(), "test"); err != nil {
+		t.Fatal(err)
+	}
+	if tok := c.Token(); !strings.HasSuffix(tok.RefreshToken, "2") {
+		t.Fatalf("expected rotated refresh token, got %+v", tok)
+	}
+}
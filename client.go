@@ -8,9 +8,12 @@ import (
 	"github.com/pkg/errors"
 	"io"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
 	"net/url"
+	"runtime"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -25,6 +28,8 @@ const (
 
 	// DefaultURL is the default api url
 	DefaultURL = "https://api.fortnox.se/3/"
+
+	maxErrorBodySize = 64 * 1024
 )
 
 var (
@@ -42,7 +47,8 @@ type AccessTokenOptions struct {
 	HTTPClient *http.Client
 }
 
-// GetAccessToken from an auth code for a client. Careful, do this only once per auth code
+// GetAccessToken from an auth code for a client. Careful, do this only once per auth code.
+// This is the legacy flow, new integrations should use OAuthConfig.Exchange
 func GetAccessToken(ctx context.Context, authorizationCode string, clientSecret string, optsFuncs ...func(*AccessTokenOptions)) (string, error) {
 
 	opts := &AccessTokenOptions{
@@ -79,11 +85,29 @@ type ClientOptions struct {
 	ClientSecret string
 	BaseURL      string
 	HTTPClient   *http.Client
+	// OAuth2 integration config, used to refresh Token
+	OAuth *OAuthConfig
+	// OAuth2 token, used instead of AccessToken when OAuth is set
+	Token *Token
+	// TokenStore persists rotated OAuth2 tokens, optional
+	TokenStore TokenStore
+	// RateLimit defaults to fortnox's quota
+	RateLimit *RateLimitOpts
+	// RateLimiter overrides RateLimit with a limiter of your own
+	RateLimiter *RateLimiter
+	// Retry policy for failed requests, defaults to DefaultRetryPolicy
+	Retry *RetryPolicy
 }
 
 // Client for fortnox api calls
 type Client struct {
 	clientOptions *ClientOptions
+
+	tokenMu sync.Mutex
+	token   *Token
+	// unsaved is set when a refreshed token couldn't be saved to the token store
+	unsaved bool
+	limiter *RateLimiter
 }
 
 // OptionsFunc sig for customising options
@@ -97,6 +121,14 @@ func WithAuthOpts(token, secret string) OptionsFunc {
 	}
 }
 
+// WithOAuthOpts helper for using OAuth2 tokens, which are refreshed automatically
+func WithOAuthOpts(config *OAuthConfig, token *Token) OptionsFunc {
+	return func(o *ClientOptions) {
+		o.OAuth = config
+		o.Token = token
+	}
+}
+
 // WithURLOpts helper for changing base url
 func WithURLOpts(url string) OptionsFunc {
 	return func(o *ClientOptions) {
@@ -117,9 +149,19 @@ func NewClient(optionsFuncs ...OptionsFunc) *Client {
 		f(o)
 	}
 
-	return &Client{
+	limiter, release := o.rateLimiter()
+	cl := &Client{
 		clientOptions: o,
+		limiter:       limiter,
+	}
+	if release != nil {
+		runtime.SetFinalizer(cl, func(*Client) { release() })
 	}
+	if o.Token != nil {
+		t := *o.Token
+		cl.token = &t
+	}
+	return cl
 }
 
 func (c *Client) makeURL(section string) (*url.URL, error) {
@@ -138,6 +180,15 @@ func (c *Client) deleteResource(ctx context.Context, resource string) error {
 	return c.request(ctx, "DELETE", resource, nil, nil, nil)
 }
 
+// getDocument gets a binary resource, such as a pdf. Set action if getting it does something, see getStream
+func (c *Client) getDocument(ctx context.Context, resource string, action bool) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	if err := c.send(ctx, "GET", resource, nil, mimeJSON, nil, buf, action); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 // MetaInformation for responses
 type MetaInformation struct {
 	CurrentPage    int `json:"@CurrentPage"`
@@ -153,6 +204,56 @@ type ErrorMessage struct {
 }
 
 func (c *Client) request(ctx context.Context, method, resource string, body interface{}, p url.Values, result interface{}) error {
+	var payload []byte
+	if strings.ToLower(method) != "delete" && body != nil {
+		bodyBuffer := new(bytes.Buffer)
+		json.NewEncoder(bodyBuffer).Encode(body)
+		payload = bodyBuffer.Bytes()
+	}
+	return c.send(ctx, method, resource, p, mimeJSON, payload, result, false)
+}
+
+// action calls an action endpoint, such as bookkeeping an invoice. These do something each time they're called, so
+// unlike other requests they aren't retried after a server error
+func (c *Client) action(ctx context.Context, method, resource string, result interface{}) error {
+	return c.send(ctx, method, resource, nil, mimeJSON, nil, result, true)
+}
+
+// upload posts a file as multipart/form-data. The file is read into memory first so the request can be retried
+func (c *Client) upload(ctx context.Context, resource string, p url.Values, filename string, r io.Reader, result interface{}) error {
+	bodyBuffer := new(bytes.Buffer)
+	mw := multipart.NewWriter(bodyBuffer)
+	part, err := mw.CreateFormFile("file", filename)
+	if err != nil {
+		return errors.Wrap(err, "error creating multipart body")
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return errors.Wrap(err, "failed to read file")
+	}
+	if err := mw.Close(); err != nil {
+		return errors.Wrap(err, "error creating multipart body")
+	}
+	return c.send(ctx, "POST", resource, p, mw.FormDataContentType(), bodyBuffer.Bytes(), result, false)
+}
+
+// stream is a successful response whose body is handed to the caller instead of being read
+type stream struct {
+	Body   io.ReadCloser
+	Header http.Header
+}
+
+// getStream gets a binary resource without buffering it. The caller must close the body.
+// Set action if getting it does something, like printing marks a document as sent
+func (c *Client) getStream(ctx context.Context, resource string, p url.Values, action bool) (*stream, error) {
+	s := &stream{}
+	if err := c.send(ctx, "GET", resource, p, mimeJSON, nil, s, action); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// send does the request with auth, rate limiting and retries. Actions are retried more carefully, see RetryPolicy.backoff
+func (c *Client) send(ctx context.Context, method, resource string, p url.Values, contentType string, payload []byte, result interface{}, action bool) error {
 	u, err := c.makeURL(resource)
 	if err != nil {
 		return err
@@ -162,20 +263,50 @@ func (c *Client) request(ctx context.Context, method, resource string, body inte
 		u.RawQuery = p.Encode()
 	}
 
-	headers := map[string]string{
-		"Authorization": fmt.Sprintf("Bearer %s", c.clientOptions.AccessToken),
-		"Client-Secret": c.clientOptions.ClientSecret,
-	}
+	policy := c.clientOptions.retryPolicy()
+	var stale *Token
+	for attempt := 1; ; attempt++ {
+		var token *Token
+		if c.usesOAuth() {
+			if token, err = c.validToken(ctx, stale); err != nil {
+				return err
+			}
+		}
 
-	if strings.ToLower(method) == "delete" {
-		bodyBuffer := http.NoBody
-		return request(ctx, c.clientOptions.HTTPClient, headers, method, u.String(), bodyBuffer, result)
-	}
+		if c.limiter != nil {
+			if err := c.limiter.Wait(ctx); err != nil {
+				return err
+			}
+		}
 
-	bodyBuffer := new(bytes.Buffer)
-	json.NewEncoder(bodyBuffer).Encode(body)
-	return request(ctx, c.clientOptions.HTTPClient, headers, method, u.String(), bodyBuffer, result)
+		var bodyReader io.Reader = http.NoBody
+		if payload != nil {
+			bodyReader = bytes.NewReader(payload)
+		}
 
+		headers := c.authHeaders(token)
+		headers["Content-Type"] = contentType
+		switch result.(type) {
+		case io.Writer, *stream:
+			headers["Accept"] = "*/*"
+		}
+
+		err = request(ctx, c.clientOptions.HTTPClient, headers, method, u.String(), bodyReader, result)
+
+		// token may have been revoked or expired early, refresh once and try again
+		if fErr, ok := err.(FnoxError); ok && fErr.HTTPStatus == http.StatusUnauthorized && token != nil && stale == nil {
+			stale = token
+			continue
+		}
+
+		if wait, ok := policy.backoff(method, action, attempt, err); ok {
+			if err := sleepCtx(ctx, wait); err != nil {
+				return err
+			}
+			continue
+		}
+		return err
+	}
 }
 
 // ErrorResp error response from fnox
@@ -188,6 +319,10 @@ type FnoxError struct {
 	HTTPStatus int
 	Code       int
 	Message    string
+	// RetryAfter is how long fortnox asked us to wait, if at all
+	RetryAfter time.Duration
+	// Body is the raw response body
+	Body string
 }
 
 // Error pretty print error
@@ -209,7 +344,7 @@ func request(ctx context.Context, client *http.Client, headers map[string]string
 	}
 
 	for k, v := range headers {
-		req.Header.Add(k, v)
+		req.Header.Set(k, v)
 	}
 
 	resp, err := client.Do(req)
@@ -218,13 +353,29 @@ func request(ctx context.Context, client *http.Client, headers map[string]string
 	}
 
 	// trick to drain body
+	keepBody := false
 	defer func() {
+		if keepBody {
+			return
+		}
 		_, _ = io.CopyN(ioutil.Discard, resp.Body, 64)
 		_ = resp.Body.Close()
 	}()
 
 	switch resp.StatusCode {
 	case 200, 201:
+		// streamed responses are closed by the caller
+		if s, ok := result.(*stream); ok {
+			keepBody = true
+			s.Body = resp.Body
+			s.Header = resp.Header
+			return nil
+		}
+		// binary responses such as pdfs
+		if w, ok := result.(io.Writer); ok {
+			_, err := io.Copy(w, resp.Body)
+			return errors.Wrap(err, "failed to read response")
+		}
 		bodyPreview, _ := getRespBodyPreview(resp, 30)
 		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 			return errors.Wrap(err, "failed to decode json from response ["+bodyPreview+"]")
@@ -235,11 +386,24 @@ func request(ctx context.Context, client *http.Client, headers map[string]string
 	default:
 		// if malformed, want to see the a
 		errMsg := &ErrorResp{}
-		bodyPreview, _ := getRespBodyPreview(resp, 128)
-		if err := json.NewDecoder(resp.Body).Decode(&errMsg); err != nil {
-			return errors.Wrap(err, fmt.Sprintf("failed to decode %d error from response [%s]", resp.StatusCode, bodyPreview))
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		fErr := FnoxError{
+			HTTPStatus: resp.StatusCode,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+			Body:       string(body),
+		}
+		if err := json.Unmarshal(body, &errMsg); err != nil {
+			// gateways send html errors, still want the status for retries
+			bodyPreview := string(body)
+			if len(bodyPreview) > 128 {
+				bodyPreview = bodyPreview[:128]
+			}
+			fErr.Message = fmt.Sprintf("failed to decode error from response [%s]", bodyPreview)
+			return fErr
 		}
-		return FnoxError{HTTPStatus: resp.StatusCode, Code: errMsg.ErrorInformation.Code, Message: errMsg.ErrorInformation.Message}
+		fErr.Code = errMsg.ErrorInformation.Code
+		fErr.Message = errMsg.ErrorInformation.Message
+		return fErr
 	}
 
 }
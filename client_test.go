@@ -1,10 +1,19 @@
 package fortnox
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"github.com/byrnedo/go-fortnox/fortnoxtest"
 	"gopkg.in/jarcoal/httpmock.v1"
+	"io/ioutil"
 	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -12,22 +21,118 @@ import (
 var (
 	accessToken = os.Getenv("FORTNOX_ACCESS_TOKEN")
 	secret      = os.Getenv("FORTNOX_CLIENT_SECRET")
+	// fake stands in for the api when no credentials are given
+	fake *fortnoxtest.Server
 )
 
 func init() {
 	rand.Seed(time.Now().UnixNano())
-	if accessToken == "" {
-		panic("must give FORTNOX_ACCESS_TOKEN env")
+	if accessToken == "" || secret == "" {
+		accessToken, secret = "fake", "fake"
+		fake = newFakeServer()
 	}
-	if secret == "" {
-		panic("must give FORTNOX_CLIENT_SECRET env")
+}
+
+// newFakeServer seeds the fake with what the tests expect to find in the test account
+func newFakeServer() *fortnoxtest.Server {
+	s := fortnoxtest.NewServer()
+	add := func(r fortnoxtest.Resource, fields map[string]interface{}) {
+		if _, err := s.Add(r, fields); err != nil {
+			panic(err)
+		}
 	}
+	add(fortnoxtest.Customers, map[string]interface{}{"CustomerNumber": "1", "Name": "Test Customer", "City": "Gothenburg"})
+	add(fortnoxtest.Articles, map[string]interface{}{"ArticleNumber": "10", "Description": "Test Article"})
+	for i := 1; i < 10; i++ {
+		row := map[string]interface{}{"Description": fmt.Sprint("row ", i), "Price": 100}
+		add(fortnoxtest.Orders, map[string]interface{}{"CustomerNumber": "1", "OrderRows": []interface{}{row}})
+		add(fortnoxtest.Invoices, map[string]interface{}{"CustomerNumber": "1", "InvoiceRows": []interface{}{row}})
+	}
+	return s
 }
 
 func addTestOpts() []OptionsFunc {
+	if fake != nil {
+		return []OptionsFunc{WithAuthOpts(accessToken, secret), WithURLOpts(fake.BaseURL()), WithRateLimitOpts(0, 0, 0)}
+	}
 	return []OptionsFunc{WithAuthOpts(accessToken, secret)}
 }
 
+// requireLive skips tests of endpoints the fake doesn't implement
+func requireLive(t *testing.T) {
+	if fake != nil {
+		t.Skip("needs FORTNOX_ACCESS_TOKEN and FORTNOX_CLIENT_SECRET")
+	}
+}
+
+// stubRequest is a request received by a stubAPI
+type stubRequest struct {
+	Query url.Values
+	Body  string
+}
+
+// stubAPI answers "METHOD path" routes with canned json, for endpoints the fake doesn't implement
+type stubAPI struct {
+	*httptest.Server
+	t        *testing.T
+	mu       sync.Mutex
+	routes   map[string]string
+	failures map[string]int
+	requests map[string][]stubRequest
+}
+
+func newStubAPI(t *testing.T, routes map[string]string) *stubAPI {
+	s := &stubAPI{t: t, routes: routes, failures: map[string]int{}, requests: map[string][]stubRequest{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+func (s *stubAPI) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	route := r.Method + " " + strings.TrimPrefix(r.URL.Path, "/")
+	body, _ := ioutil.ReadAll(r.Body)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests[route] = append(s.requests[route], stubRequest{Query: r.URL.Query(), Body: string(body)})
+	resp, ok := s.routes[route]
+	if !ok {
+		s.t.Errorf("unexpected request %s", route)
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"ErrorInformation":{"Error":1,"Message":"not found","Code":0}}`)
+		return
+	}
+	if status := s.failures[route]; status != 0 {
+		w.WriteHeader(status)
+		fmt.Fprint(w, `{"ErrorInformation":{"Error":1,"Message":"failed","Code":0}}`)
+		return
+	}
+	if resp == "" {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	fmt.Fprint(w, resp)
+}
+
+// fail makes a route answer with the status
+func (s *stubAPI) fail(route string, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[route] = status
+}
+
+// received returns the requests made to a route
+func (s *stubAPI) received(route string) []stubRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]stubRequest(nil), s.requests[route]...)
+}
+
+// client retries quickly so failures can be tested
+func (s *stubAPI) client() *Client {
+	return NewClient(WithAuthOpts("stub", "secret"), WithURLOpts(s.URL+"/"), WithRateLimitOpts(0, 0, 0),
+		WithRetryOpts(RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}))
+}
+
 func TestGetAccessToken(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
@@ -122,6 +227,17 @@ func TestGetInvoice(t *testing.T) {
 
 }
 
+func TestClient_PreviewInvoice(t *testing.T) {
+	c := NewClient(addTestOpts()...)
+	pdf, err := c.PreviewInvoice(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(pdf, []byte("%PDF")) {
+		t.Fatal("Response was not a pdf")
+	}
+}
+
 func TestClient_GetCompanySettings(t *testing.T) {
 	c := NewClient(addTestOpts()...)
 	r, err := c.GetCompanySettings(context.Background())
@@ -364,6 +480,81 @@ func TestClient_UpdateOrder(t *testing.T) {
 
 }
 
+func TestClient_CreateInvoiceFromOrder(t *testing.T) {
+
+	var (
+		c    = NewClient(addTestOpts()...)
+		one  = "1"
+		desc = "Desc Text"
+	)
+
+	order := &CreateOrder{
+		CustomerNumber: &one,
+		OrderRows: []*CreateOrderRow{
+			{Description: &desc},
+		},
+	}
+	r, err := c.CreateOrder(context.Background(), order)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inv, err := c.CreateInvoiceFromOrder(context.Background(), int(r.DocumentNumber))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(inv.InvoiceRows) != 1 {
+		t.Fatalf("unexpected number of invoice rows, expected 1, got %d", len(inv.InvoiceRows))
+	}
+
+	checkTextInvoiceRow(inv.InvoiceRows[0], desc, t)
+}
+
+func TestClient_CreateUpdateOffer(t *testing.T) {
+	requireLive(t)
+
+	var (
+		c    = NewClient(addTestOpts()...)
+		one  = "1"
+		desc = "Desc Text"
+		gbg  = "Gothenburg"
+	)
+
+	offer := &CreateOffer{
+		CustomerNumber: &one,
+		OfferRows: []*CreateOfferRow{
+			{Description: &desc},
+		},
+	}
+	r, err := c.CreateOffer(context.Background(), offer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	update := &UpdateOffer{
+		CustomerNumber: &one,
+		DeliveryCity:   &gbg,
+	}
+	r, err = c.UpdateOffer(context.Background(), int(r.DocumentNumber), update)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.DeliveryCity != gbg {
+		t.Fatalf("unexpected delivery city: %s", r.DeliveryCity)
+	}
+
+	order, err := c.CreateOrderFromOffer(context.Background(), int(r.DocumentNumber))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(order.OrderRows) != 1 {
+		t.Fatalf("unexpected number of order rows, expected 1, got %d", len(order.OrderRows))
+	}
+}
+
 func checkTextOrderRow(row OrderRow, desc string, t *testing.T) {
 	if row.Description != desc {
 		t.Fatalf("unexpected description: %s", row.Description)
@@ -507,3 +698,465 @@ func TestClient_ListCustomers(t *testing.T) {
 	}
 
 }
+
+func TestClient_CreateUpdateDeleteSupplier(t *testing.T) {
+	requireLive(t)
+
+	c := NewClient(addTestOpts()...)
+	name := "test supplier " + RandStringBytes(5)
+	r1, err := c.CreateSupplier(context.Background(), &CreateSupplier{
+		Name: &name,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	name2 := name + "update"
+	r2, err := c.UpdateSupplier(context.Background(), r1.SupplierNumber, &UpdateSupplier{
+		Name: &name2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r2.Name != name2 {
+		t.Fatalf("unexpected name: %s", r2.Name)
+	}
+
+	resp, err := c.ListSuppliers(context.Background(), &SupplierQueryParams{Name: name2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.Suppliers) != 1 {
+		t.Fatalf("unexpected number of suppliers, expected 1, got %d", len(resp.Suppliers))
+	}
+
+	err = c.DeleteSupplier(context.Background(), r1.SupplierNumber)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClient_ListSupplierInvoices(t *testing.T) {
+	requireLive(t)
+	c := NewClient(addTestOpts()...)
+
+	r, err := c.ListSupplierInvoices(context.Background(), &SupplierInvoiceQueryParams{Filter: "unbooked"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.MetaInformation == nil {
+		t.Fatal("Meta was nil")
+	}
+
+	if r.SupplierInvoices == nil {
+		t.Fatal("Response was nil")
+	}
+}
+
+func TestClient_ListVouchers(t *testing.T) {
+	requireLive(t)
+	c := NewClient(addTestOpts()...)
+
+	series, err := c.ListVoucherSeries(context.Background(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(series.VoucherSeriesCollection) == 0 {
+		t.Fatal("expected voucher series")
+	}
+
+	r, err := c.ListVouchers(context.Background(), &VoucherQueryParams{Series: series.VoucherSeriesCollection[0].Code})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.MetaInformation == nil {
+		t.Fatal("Meta was nil")
+	}
+
+	if len(r.Vouchers) == 0 {
+		return
+	}
+
+	v, err := c.GetVoucher(context.Background(), r.Vouchers[0].VoucherSeries, r.Vouchers[0].VoucherNumber.Int(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.VoucherNumber != r.Vouchers[0].VoucherNumber {
+		t.Fatal("Wrong voucher")
+	}
+}
+
+func TestClient_ListAccounts(t *testing.T) {
+	requireLive(t)
+	c := NewClient(addTestOpts()...)
+
+	r, err := c.ListAccounts(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.MetaInformation == nil {
+		t.Fatal("Meta was nil")
+	}
+	if len(r.Accounts) == 0 {
+		t.Fatal("expected accounts")
+	}
+
+	a, err := c.GetAccount(context.Background(), r.Accounts[0].Number, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Number != r.Accounts[0].Number {
+		t.Fatal("Wrong account")
+	}
+}
+
+func TestClient_GetFinancialYearByDate(t *testing.T) {
+	requireLive(t)
+	c := NewClient(addTestOpts()...)
+
+	y, err := c.GetFinancialYearByDate(context.Background(), time.Now().Format("2006-01-02"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	y2, err := c.GetFinancialYear(context.Background(), y.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if y2.ID != y.ID {
+		t.Fatal("Wrong financial year")
+	}
+
+	if _, err := c.GetFinancialYearByDate(context.Background(), "1900-01-01"); !IsNotFound(err) {
+		t.Fatal("expected not found, got", err)
+	}
+}
+
+func TestClient_ListInvoicePayments(t *testing.T) {
+	requireLive(t)
+	c := NewClient(addTestOpts()...)
+
+	r, err := c.ListInvoicePayments(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.MetaInformation == nil {
+		t.Fatal("Meta was nil")
+	}
+
+	if r.InvoicePayments == nil {
+		t.Fatal("Response was nil")
+	}
+}
+
+func TestClient_ListSupplierInvoicePayments(t *testing.T) {
+	requireLive(t)
+	c := NewClient(addTestOpts()...)
+
+	r, err := c.ListSupplierInvoicePayments(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.MetaInformation == nil {
+		t.Fatal("Meta was nil")
+	}
+
+	if r.SupplierInvoicePayments == nil {
+		t.Fatal("Response was nil")
+	}
+}
+
+func TestClient_CreateUpdateDeleteProject(t *testing.T) {
+	requireLive(t)
+
+	c := NewClient(addTestOpts()...)
+	desc := "test project " + RandStringBytes(5)
+	r1, err := c.CreateProject(context.Background(), &CreateProject{
+		Description: &desc,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	status := ProjectStatusOngoing
+	r2, err := c.UpdateProject(context.Background(), r1.ProjectNumber, &UpdateProject{
+		Status: &status,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r2.Status != status {
+		t.Fatalf("unexpected status: %s", r2.Status)
+	}
+
+	err = c.DeleteProject(context.Background(), r1.ProjectNumber)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClient_CreateUpdateDeleteCostCenter(t *testing.T) {
+	requireLive(t)
+
+	c := NewClient(addTestOpts()...)
+	code := RandStringBytes(6)
+	desc := "test cost center"
+	r1, err := c.CreateCostCenter(context.Background(), &CreateCostCenter{
+		Code:        &code,
+		Description: &desc,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	desc2 := desc + "update"
+	r2, err := c.UpdateCostCenter(context.Background(), r1.Code, &UpdateCostCenter{
+		Description: &desc2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r2.Description != desc2 {
+		t.Fatalf("unexpected description: %s", r2.Description)
+	}
+
+	err = c.DeleteCostCenter(context.Background(), r1.Code)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClient_PriceListPrices(t *testing.T) {
+	requireLive(t)
+
+	c := NewClient(addTestOpts()...)
+	code := RandStringBytes(5)
+	desc := "test price list"
+	list, err := c.CreatePriceList(context.Background(), &CreatePriceList{
+		Code:        &code,
+		Description: &desc,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	artNum := RandStringBytes(5)
+	if _, err := c.CreateArticle(context.Background(), &CreateArticle{ArticleNumber: &artNum, Description: &desc}); err != nil {
+		t.Fatal(err)
+	}
+	defer c.DeleteArticle(context.Background(), artNum)
+
+	base, breakQty, basePrice, breakPrice := 0.0, 10.0, 100.0, 80.0
+	for _, p := range []*CreatePrice{
+		{ArticleNumber: &artNum, PriceList: &list.Code, FromQuantity: &base, Price: &basePrice},
+		{ArticleNumber: &artNum, PriceList: &list.Code, FromQuantity: &breakQty, Price: &breakPrice},
+	} {
+		if _, err := c.CreatePrice(context.Background(), p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	prices, err := c.ListArticlePrices(context.Background(), list.Code, artNum, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prices.Prices) != 2 {
+		t.Fatalf("unexpected number of prices, expected 2, got %d", len(prices.Prices))
+	}
+
+	newPrice := 75.0
+	p, err := c.UpdatePrice(context.Background(), list.Code, artNum, breakQty, &UpdatePrice{Price: &newPrice})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Price.Float64() != newPrice {
+		t.Fatalf("unexpected price: %f", p.Price.Float64())
+	}
+
+	if err := c.DeletePrice(context.Background(), list.Code, artNum, breakQty); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClient_CreateUpdateDeleteUnit(t *testing.T) {
+	requireLive(t)
+
+	c := NewClient(addTestOpts()...)
+	code := RandStringBytes(3)
+	desc := "test unit"
+	r1, err := c.CreateUnit(context.Background(), &CreateUnit{
+		Code:        &code,
+		Description: &desc,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	desc2 := desc + "update"
+	r2, err := c.UpdateUnit(context.Background(), r1.Code, &UpdateUnit{
+		Description: &desc2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r2.Description != desc2 {
+		t.Fatalf("unexpected description: %s", r2.Description)
+	}
+
+	l := NewLookup(c, 0)
+	if _, err := l.Unit(context.Background(), code); err != nil {
+		t.Fatal(err)
+	}
+
+	err = c.DeleteUnit(context.Background(), r1.Code)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClient_ListReferenceRegisters(t *testing.T) {
+	requireLive(t)
+	c := NewClient(addTestOpts()...)
+	ctx := context.Background()
+
+	if _, err := c.ListTermsOfPayments(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ListTermsOfDeliveries(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ListWayOfDeliveries(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ListModesOfPayments(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewLookup(c, 0).Currency(ctx, "SEK"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClient_CreateContract(t *testing.T) {
+	requireLive(t)
+
+	var (
+		c        = NewClient(addTestOpts()...)
+		one      = "1"
+		desc     = "Subscription"
+		interval = 1
+		length   = 12
+		start    = time.Now().Format("2006-01-02")
+	)
+
+	r, err := c.CreateContract(context.Background(), &CreateContract{
+		CustomerNumber:  &one,
+		ContractDate:    &start,
+		PeriodStart:     &start,
+		InvoiceInterval: &interval,
+		ContractLength:  &length,
+		InvoiceRows: []*CreateInvoiceRow{
+			{Description: &desc},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err = c.IncreaseInvoiceCountContract(context.Background(), r.DocumentNumber.Int())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.InvoicesRemaining.Int() != length-1 {
+		t.Fatalf("unexpected invoices remaining: %d", r.InvoicesRemaining.Int())
+	}
+
+	if _, err := c.FinishContract(context.Background(), r.DocumentNumber.Int()); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.ListContractTemplates(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClient_UploadDownloadDeleteArchiveFile(t *testing.T) {
+	requireLive(t)
+	c := NewClient(addTestOpts()...)
+
+	content := "test file " + RandStringBytes(10)
+	f, err := c.UploadArchiveFile(context.Background(), "", RandStringBytes(8)+".txt", strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body, err := c.DownloadArchiveFile(context.Background(), f.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(body)
+	body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != content {
+		t.Fatalf("unexpected content: %s", data)
+	}
+
+	folder, err := c.GetArchiveFolder(context.Background(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if folder.Files == nil {
+		t.Fatal("expected files")
+	}
+
+	err = c.DeleteArchiveFile(context.Background(), f.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClient_AttachFileToInvoice(t *testing.T) {
+	requireLive(t)
+	c := NewClient(addTestOpts()...)
+
+	a, err := c.AttachFileToInvoice(context.Background(), 1, RandStringBytes(8)+".txt", strings.NewReader("test attachment"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attachments, err := c.ListAttachments(context.Background(), AttachmentEntity{Type: AttachmentEntityInvoice, ID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(attachments) == 0 {
+		t.Fatal("expected attachments")
+	}
+
+	err = c.DeleteAttachment(context.Background(), a.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClient_PreviewOrderPDF(t *testing.T) {
+	c := NewClient(addTestOpts()...)
+	doc, err := c.PreviewOrderPDF(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer doc.Close()
+
+	pdf, err := ioutil.ReadAll(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(pdf, []byte("%PDF")) {
+		t.Fatal("Response was not a pdf")
+	}
+}
@@ -0,0 +1,251 @@
+package fortnox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Contract filters
+const (
+	ContractFilterActive   = "active"
+	ContractFilterInactive = "inactive"
+	ContractFilterFinished = "finished"
+)
+
+// ContractShort data type
+type ContractShort struct {
+	URL               string   `json:"@url"`
+	Continuous        bool     `json:"Continuous"`
+	ContractLength    Intish   `json:"ContractLength"`
+	Currency          string   `json:"Currency"`
+	CustomerName      string   `json:"CustomerName"`
+	CustomerNumber    string   `json:"CustomerNumber"`
+	DocumentNumber    Intish   `json:"DocumentNumber"`
+	InvoiceInterval   Intish   `json:"InvoiceInterval"`
+	InvoicesRemaining Intish   `json:"InvoicesRemaining"`
+	LastInvoiceDate   Date     `json:"LastInvoiceDate"`
+	PeriodEnd         Date     `json:"PeriodEnd"`
+	PeriodStart       Date     `json:"PeriodStart"`
+	Status            string   `json:"Status"`
+	TemplateNumber    Intish   `json:"TemplateNumber"`
+	Total             Floatish `json:"Total"`
+}
+
+// ContractFull data type
+type ContractFull struct {
+	URL                       string           `json:"@url"`
+	Active                    bool             `json:"Active"`
+	AdministrationFee         Floatish         `json:"AdministrationFee"`
+	BasisTotal                Floatish         `json:"BasisTotal"`
+	Comments                  string           `json:"Comments"`
+	Continuous                bool             `json:"Continuous"`
+	ContractDate              Date             `json:"ContractDate"`
+	ContractLength            Intish           `json:"ContractLength"`
+	CostCenter                string           `json:"CostCenter"`
+	Currency                  string           `json:"Currency"`
+	CustomerName              string           `json:"CustomerName"`
+	CustomerNumber            string           `json:"CustomerNumber"`
+	DocumentNumber            Intish           `json:"DocumentNumber"`
+	EmailInformation          EmailInformation `json:"EmailInformation"`
+	ExternalInvoiceReference1 string           `json:"ExternalInvoiceReference1"`
+	ExternalInvoiceReference2 string           `json:"ExternalInvoiceReference2"`
+	Freight                   Floatish         `json:"Freight"`
+	InvoiceInterval           Intish           `json:"InvoiceInterval"`
+	InvoiceRows               []InvoiceRow     `json:"InvoiceRows"`
+	InvoicesRemaining         Intish           `json:"InvoicesRemaining"`
+	Language                  string           `json:"Language"`
+	LastInvoiceDate           Date             `json:"LastInvoiceDate"`
+	Net                       Floatish         `json:"Net"`
+	OurReference              string           `json:"OurReference"`
+	PeriodEnd                 Date             `json:"PeriodEnd"`
+	PeriodStart               Date             `json:"PeriodStart"`
+	PriceList                 string           `json:"PriceList"`
+	PrintTemplate             string           `json:"PrintTemplate"`
+	Project                   string           `json:"Project"`
+	Remarks                   string           `json:"Remarks"`
+	Status                    string           `json:"Status"`
+	TaxReduction              Floatish         `json:"TaxReduction"`
+	TemplateName              string           `json:"TemplateName"`
+	TemplateNumber            Intish           `json:"TemplateNumber"`
+	TermsOfDelivery           string           `json:"TermsOfDelivery"`
+	TermsOfPayment            string           `json:"TermsOfPayment"`
+	Total                     Floatish         `json:"Total"`
+	TotalToPay                Floatish         `json:"TotalToPay"`
+	TotalVAT                  Floatish         `json:"TotalVAT"`
+	VATIncluded               bool             `json:"VATIncluded"`
+	WayOfDelivery             string           `json:"WayOfDelivery"`
+	YourOrderNumber           string           `json:"YourOrderNumber"`
+	YourReference             string           `json:"YourReference"`
+}
+
+// CreateContract payload for creating contracts.
+// InvoiceInterval is the number of months between invoices, ContractLength the number of months the contract runs for
+type CreateContract struct {
+	Active                    *bool               `json:"Active,omitempty"`
+	AdministrationFee         *float64            `json:"AdministrationFee,omitempty"`
+	Comments                  *string             `json:"Comments,omitempty"`
+	Continuous                *bool               `json:"Continuous,omitempty"`
+	ContractDate              *string             `json:"ContractDate,omitempty"`
+	ContractLength            *int                `json:"ContractLength,omitempty"`
+	CostCenter                *string             `json:"CostCenter,omitempty"`
+	Currency                  *string             `json:"Currency,omitempty"`
+	CustomerNumber            *string             `json:"CustomerNumber,omitempty"`
+	EmailInformation          *EmailInformation   `json:"EmailInformation,omitempty"`
+	ExternalInvoiceReference1 *string             `json:"ExternalInvoiceReference1,omitempty"`
+	ExternalInvoiceReference2 *string             `json:"ExternalInvoiceReference2,omitempty"`
+	Freight                   *float64            `json:"Freight,omitempty"`
+	InvoiceInterval           *int                `json:"InvoiceInterval,omitempty"`
+	InvoiceRows               []*CreateInvoiceRow `json:"InvoiceRows,omitempty"`
+	Language                  *string             `json:"Language,omitempty"`
+	OurReference              *string             `json:"OurReference,omitempty"`
+	PeriodEnd                 *string             `json:"PeriodEnd,omitempty"`
+	PeriodStart               *string             `json:"PeriodStart,omitempty"`
+	PriceList                 *string             `json:"PriceList,omitempty"`
+	PrintTemplate             *string             `json:"PrintTemplate,omitempty"`
+	Project                   *string             `json:"Project,omitempty"`
+	Remarks                   *string             `json:"Remarks,omitempty"`
+	TemplateNumber            *int                `json:"TemplateNumber,omitempty"`
+	TermsOfDelivery           *string             `json:"TermsOfDelivery,omitempty"`
+	TermsOfPayment            *string             `json:"TermsOfPayment,omitempty"`
+	VATIncluded               *bool               `json:"VATIncluded,omitempty"`
+	WayOfDelivery             *string             `json:"WayOfDelivery,omitempty"`
+	YourOrderNumber           *string             `json:"YourOrderNumber,omitempty"`
+	YourReference             *string             `json:"YourReference,omitempty"`
+}
+
+// UpdateContract payload for updating contracts
+type UpdateContract CreateContract
+
+// ContractQueryParams when searching contracts. Filter is one of the ContractFilter constants
+type ContractQueryParams struct {
+	Filter       string
+	LastModified time.Time
+	Page         int
+	Limit        int
+	Offset       int
+	Extra        map[string][]string
+}
+
+func (p ContractQueryParams) toValues() url.Values {
+
+	ret := make(url.Values)
+
+	if len(p.Filter) > 0 {
+		ret["filter"] = []string{p.Filter}
+	}
+	if !p.LastModified.IsZero() {
+		ret["lastmodified"] = []string{p.LastModified.Format(TimeFormat)}
+	}
+	if p.Limit > 0 {
+		ret["limit"] = []string{fmt.Sprintf("%d", p.Limit)}
+	}
+	if p.Offset > 0 {
+		ret["offset"] = []string{fmt.Sprintf("%d", p.Offset)}
+	}
+	if p.Page > 0 {
+		ret["page"] = []string{fmt.Sprintf("%d", p.Page)}
+	}
+	for k, vs := range p.Extra {
+		ret[k] = vs
+	}
+	return ret
+}
+
+// ListContractsResp Response when listing contracts
+type ListContractsResp struct {
+	Contracts       []*ContractShort `json:"Contracts"`
+	MetaInformation *MetaInformation `json:"MetaInformation"`
+}
+
+// ListContracts lists contracts
+func (c *Client) ListContracts(ctx context.Context, p *ContractQueryParams) (*ListContractsResp, error) {
+	resp := &ListContractsResp{}
+
+	var vals url.Values
+	if p != nil {
+		vals = p.toValues()
+	}
+
+	err := c.request(ctx, "GET", "contracts", nil, vals, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ContractResp Response for single contract
+type ContractResp struct {
+	Contract ContractFull `json:"Contract"`
+}
+
+// GetContract gets one contract by id
+func (c *Client) GetContract(ctx context.Context, id int) (*ContractFull, error) {
+
+	resp := &ContractResp{}
+
+	err := c.request(ctx, "GET", fmt.Sprintf("contracts/%d", id), nil, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Contract, nil
+}
+
+// CreateContract creates a contract
+func (c *Client) CreateContract(ctx context.Context, contract *CreateContract) (*ContractFull, error) {
+	resp := &ContractResp{}
+	err := c.request(ctx, "POST", "contracts/", &struct {
+		Contract *CreateContract `json:"Contract"`
+	}{
+		Contract: contract,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Contract, nil
+}
+
+// UpdateContract updates a contract
+func (c *Client) UpdateContract(ctx context.Context, id int, contract *UpdateContract) (*ContractFull, error) {
+	resp := &ContractResp{}
+	err := c.request(ctx, "PUT", fmt.Sprintf("contracts/%d", id), &struct {
+		Contract *UpdateContract `json:"Contract"`
+	}{
+		Contract: contract,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Contract, nil
+}
+
+func (c *Client) contractAction(ctx context.Context, method string, id int, action string) (*ContractFull, error) {
+
+	resp := &ContractResp{}
+	err := c.action(ctx, method, fmt.Sprintf("contracts/%d/%s", id, action), resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Contract, nil
+}
+
+// FinishContract finishes a contract, no more invoices will be created from it
+func (c *Client) FinishContract(ctx context.Context, id int) (*ContractFull, error) {
+	return c.contractAction(ctx, "PUT", id, "finish")
+}
+
+// CreateInvoiceFromContract creates the next invoice of a contract, returning the updated contract
+func (c *Client) CreateInvoiceFromContract(ctx context.Context, id int) (*ContractFull, error) {
+	return c.contractAction(ctx, "PUT", id, "createinvoice")
+}
+
+// IncreaseInvoiceCountContract counts an invoice towards the contract without creating one,
+// e.g. when the period was invoiced some other way
+func (c *Client) IncreaseInvoiceCountContract(ctx context.Context, id int) (*ContractFull, error) {
+	return c.contractAction(ctx, "PUT", id, "increaseinvoicecount")
+}
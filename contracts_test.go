@@ -0,0 +1,62 @@
+package fortnox
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestClient_Contracts(t *testing.T) {
+	api := newStubAPI(t, map[string]string{
+		"GET contracts":                 `{"Contracts":[{"DocumentNumber":"2","CustomerNumber":"1","Status":"ACTIVE","InvoicesRemaining":"10","PeriodStart":"2020-01-01"}],"MetaInformation":{"@CurrentPage":1,"@TotalPages":1,"@TotalResources":1}}`,
+		"POST contracts/":               `{"Contract":{"DocumentNumber":"2","CustomerNumber":"1","TemplateNumber":"1","InvoicesRemaining":"12"}}`,
+		"PUT contracts/2/createinvoice": `{"Contract":{"DocumentNumber":"2","InvoicesRemaining":"11"}}`,
+		"PUT contracts/2/finish":        `{"Contract":{"DocumentNumber":"2","Status":"FINISHED"}}`,
+		"GET contracttemplates/1":       `{"ContractTemplate":{"TemplateNumber":"1","TemplateName":"Månadsavgift","InvoiceInterval":"1"}}`,
+	})
+	defer api.Close()
+	c := api.client()
+	ctx := context.Background()
+
+	list, err := c.ListContracts(ctx, &ContractQueryParams{Filter: ContractFilterActive})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Contracts) != 1 || list.Contracts[0].InvoicesRemaining != 10 {
+		t.Fatalf("unexpected contracts %+v", list.Contracts)
+	}
+	if q := api.received("GET contracts")[0].Query; q.Get("filter") != "active" {
+		t.Fatal("unexpected query", q)
+	}
+
+	tmpl, err := c.GetContractTemplate(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	customer, template := "1", int(tmpl.TemplateNumber)
+	contract, err := c.CreateContract(ctx, &CreateContract{CustomerNumber: &customer, TemplateNumber: &template})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	contract, err = c.CreateInvoiceFromContract(ctx, int(contract.DocumentNumber))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if contract.InvoicesRemaining != 11 {
+		t.Fatal("unexpected invoices remaining", contract.InvoicesRemaining)
+	}
+
+	// creating an invoice isn't idempotent, so it isn't retried
+	api.fail("PUT contracts/2/createinvoice", http.StatusInternalServerError)
+	if _, err := c.CreateInvoiceFromContract(ctx, 2); !IsServerError(err) {
+		t.Fatal("expected server error, got", err)
+	}
+	if n := len(api.received("PUT contracts/2/createinvoice")); n != 2 {
+		t.Fatal("expected 2 requests, got", n)
+	}
+
+	if contract, err = c.FinishContract(ctx, 2); err != nil || contract.Status != "FINISHED" {
+		t.Fatal("unexpected contract", contract, err)
+	}
+}
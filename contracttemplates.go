@@ -0,0 +1,102 @@
+package fortnox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// ContractTemplateShort data type
+type ContractTemplateShort struct {
+	URL             string `json:"@url"`
+	ContractLength  Intish `json:"ContractLength"`
+	InvoiceInterval Intish `json:"InvoiceInterval"`
+	TemplateName    string `json:"TemplateName"`
+	TemplateNumber  Intish `json:"TemplateNumber"`
+}
+
+// ContractTemplate data type
+type ContractTemplate struct {
+	URL               string       `json:"@url"`
+	AdministrationFee Floatish     `json:"AdministrationFee"`
+	ContractLength    Intish       `json:"ContractLength"`
+	Continuous        bool         `json:"Continuous"`
+	Freight           Floatish     `json:"Freight"`
+	InvoiceInterval   Intish       `json:"InvoiceInterval"`
+	InvoiceRows       []InvoiceRow `json:"InvoiceRows"`
+	OurReference      string       `json:"OurReference"`
+	PrintTemplate     string       `json:"PrintTemplate"`
+	Remarks           string       `json:"Remarks"`
+	TemplateName      string       `json:"TemplateName"`
+	TemplateNumber    Intish       `json:"TemplateNumber"`
+	TermsOfDelivery   string       `json:"TermsOfDelivery"`
+	TermsOfPayment    string       `json:"TermsOfPayment"`
+	WayOfDelivery     string       `json:"WayOfDelivery"`
+}
+
+// ContractTemplateQueryParams when listing contract templates
+type ContractTemplateQueryParams struct {
+	Page   int
+	Limit  int
+	Offset int
+	Extra  map[string][]string
+}
+
+func (p ContractTemplateQueryParams) toValues() url.Values {
+
+	ret := make(url.Values)
+
+	if p.Limit > 0 {
+		ret["limit"] = []string{fmt.Sprintf("%d", p.Limit)}
+	}
+	if p.Offset > 0 {
+		ret["offset"] = []string{fmt.Sprintf("%d", p.Offset)}
+	}
+	if p.Page > 0 {
+		ret["page"] = []string{fmt.Sprintf("%d", p.Page)}
+	}
+	for k, vs := range p.Extra {
+		ret[k] = vs
+	}
+	return ret
+}
+
+// ListContractTemplatesResp Response when listing contract templates
+type ListContractTemplatesResp struct {
+	ContractTemplates []*ContractTemplateShort `json:"ContractTemplates"`
+	MetaInformation   *MetaInformation         `json:"MetaInformation"`
+}
+
+// ListContractTemplates lists contract templates
+func (c *Client) ListContractTemplates(ctx context.Context, p *ContractTemplateQueryParams) (*ListContractTemplatesResp, error) {
+	resp := &ListContractTemplatesResp{}
+
+	var vals url.Values
+	if p != nil {
+		vals = p.toValues()
+	}
+
+	err := c.request(ctx, "GET", "contracttemplates", nil, vals, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ContractTemplateResp Response for single contract template
+type ContractTemplateResp struct {
+	ContractTemplate ContractTemplate `json:"ContractTemplate"`
+}
+
+// GetContractTemplate gets one contract template by number
+func (c *Client) GetContractTemplate(ctx context.Context, templateNum int) (*ContractTemplate, error) {
+
+	resp := &ContractTemplateResp{}
+
+	err := c.request(ctx, "GET", fmt.Sprintf("contracttemplates/%d", templateNum), nil, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.ContractTemplate, nil
+}
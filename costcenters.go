@@ -0,0 +1,129 @@
+package fortnox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// CostCenter data type
+type CostCenter struct {
+	URL         string `json:"@url"`
+	Active      bool   `json:"Active"`
+	Code        string `json:"Code"`
+	Description string `json:"Description"`
+	Note        string `json:"Note"`
+}
+
+// CreateCostCenter payload for creating cost centers
+type CreateCostCenter struct {
+	Active      *bool   `json:"Active,omitempty"`
+	Code        *string `json:"Code,omitempty"`
+	Description *string `json:"Description,omitempty"`
+	Note        *string `json:"Note,omitempty"`
+}
+
+// UpdateCostCenter payload for updating cost centers
+type UpdateCostCenter CreateCostCenter
+
+// CostCenterQueryParams when searching cost centers
+type CostCenterQueryParams struct {
+	Page   int
+	Limit  int
+	Offset int
+	Extra  map[string][]string
+}
+
+func (p CostCenterQueryParams) toValues() url.Values {
+
+	ret := make(url.Values)
+
+	if p.Limit > 0 {
+		ret["limit"] = []string{fmt.Sprintf("%d", p.Limit)}
+	}
+	if p.Offset > 0 {
+		ret["offset"] = []string{fmt.Sprintf("%d", p.Offset)}
+	}
+	if p.Page > 0 {
+		ret["page"] = []string{fmt.Sprintf("%d", p.Page)}
+	}
+	for k, vs := range p.Extra {
+		ret[k] = vs
+	}
+	return ret
+}
+
+// ListCostCentersResp Response when listing cost centers
+type ListCostCentersResp struct {
+	CostCenters     []*CostCenter    `json:"CostCenters"`
+	MetaInformation *MetaInformation `json:"MetaInformation"`
+}
+
+// ListCostCenters lists cost centers
+func (c *Client) ListCostCenters(ctx context.Context, p *CostCenterQueryParams) (*ListCostCentersResp, error) {
+	resp := &ListCostCentersResp{}
+
+	var vals url.Values
+	if p != nil {
+		vals = p.toValues()
+	}
+
+	err := c.request(ctx, "GET", "costcenters", nil, vals, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CostCenterResp Response for single cost center
+type CostCenterResp struct {
+	CostCenter CostCenter `json:"CostCenter"`
+}
+
+// GetCostCenter gets one cost center by code
+func (c *Client) GetCostCenter(ctx context.Context, code string) (*CostCenter, error) {
+
+	resp := &CostCenterResp{}
+
+	err := c.request(ctx, "GET", "costcenters/"+url.PathEscape(code), nil, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.CostCenter, nil
+}
+
+// CreateCostCenter creates a cost center
+func (c *Client) CreateCostCenter(ctx context.Context, costCenter *CreateCostCenter) (*CostCenter, error) {
+	resp := &CostCenterResp{}
+	err := c.request(ctx, "POST", "costcenters/", &struct {
+		CostCenter *CreateCostCenter `json:"CostCenter"`
+	}{
+		CostCenter: costCenter,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.CostCenter, nil
+}
+
+// UpdateCostCenter updates a cost center
+func (c *Client) UpdateCostCenter(ctx context.Context, code string, costCenter *UpdateCostCenter) (*CostCenter, error) {
+	resp := &CostCenterResp{}
+	err := c.request(ctx, "PUT", "costcenters/"+url.PathEscape(code), &struct {
+		CostCenter *UpdateCostCenter `json:"CostCenter"`
+	}{
+		CostCenter: costCenter,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.CostCenter, nil
+}
+
+// DeleteCostCenter deletes one cost center
+func (c *Client) DeleteCostCenter(ctx context.Context, code string) error {
+	return c.deleteResource(ctx, "costcenters/"+url.PathEscape(code))
+}
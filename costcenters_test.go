@@ -0,0 +1,39 @@
+package fortnox
+
+import (
+	"context"
+	"testing"
+)
+
+func TestClient_CostCenters(t *testing.T) {
+	api := newStubAPI(t, map[string]string{
+		"GET costcenters":           `{"CostCenters":[{"Code":"SÄLJ 1","Description":"Försäljning","Active":true}],"MetaInformation":{"@CurrentPage":1,"@TotalPages":1,"@TotalResources":1}}`,
+		"GET costcenters/SÄLJ 1":    `{"CostCenter":{"Code":"SÄLJ 1","Description":"Försäljning","Active":true}}`,
+		"PUT costcenters/SÄLJ 1":    `{"CostCenter":{"Code":"SÄLJ 1","Description":"Försäljning","Active":false}}`,
+		"DELETE costcenters/SÄLJ 1": ``,
+	})
+	defer api.Close()
+	c := api.client()
+	ctx := context.Background()
+
+	list, err := c.ListCostCenters(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.CostCenters) != 1 || list.CostCenters[0].Code != "SÄLJ 1" {
+		t.Fatalf("unexpected cost centers %+v", list.CostCenters)
+	}
+
+	// codes are escaped in the path
+	cc, err := c.GetCostCenter(ctx, "SÄLJ 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	active := false
+	if cc, err = c.UpdateCostCenter(ctx, cc.Code, &UpdateCostCenter{Active: &active}); err != nil || cc.Active {
+		t.Fatal("unexpected cost center", cc, err)
+	}
+	if err := c.DeleteCostCenter(ctx, cc.Code); err != nil {
+		t.Fatal(err)
+	}
+}
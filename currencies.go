@@ -0,0 +1,134 @@
+package fortnox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Currency data type
+type Currency struct {
+	URL         string   `json:"@url"`
+	BuyRate     Floatish `json:"BuyRate"`
+	Code        string   `json:"Code"`
+	Date        Date     `json:"Date"`
+	Description string   `json:"Description"`
+	IsAutomatic bool     `json:"IsAutomatic"`
+	SellRate    Floatish `json:"SellRate"`
+	Unit        Floatish `json:"Unit"`
+}
+
+// CreateCurrency payload for creating currencies
+type CreateCurrency struct {
+	BuyRate     *float64 `json:"BuyRate,omitempty"`
+	Code        *string  `json:"Code,omitempty"`
+	Description *string  `json:"Description,omitempty"`
+	IsAutomatic *bool    `json:"IsAutomatic,omitempty"`
+	SellRate    *float64 `json:"SellRate,omitempty"`
+	Unit        *float64 `json:"Unit,omitempty"`
+}
+
+// UpdateCurrency payload for updating currencies
+type UpdateCurrency CreateCurrency
+
+// CurrencyQueryParams when listing currencies
+type CurrencyQueryParams struct {
+	Page   int
+	Limit  int
+	Offset int
+	Extra  map[string][]string
+}
+
+func (p CurrencyQueryParams) toValues() url.Values {
+
+	ret := make(url.Values)
+
+	if p.Limit > 0 {
+		ret["limit"] = []string{fmt.Sprintf("%d", p.Limit)}
+	}
+	if p.Offset > 0 {
+		ret["offset"] = []string{fmt.Sprintf("%d", p.Offset)}
+	}
+	if p.Page > 0 {
+		ret["page"] = []string{fmt.Sprintf("%d", p.Page)}
+	}
+	for k, vs := range p.Extra {
+		ret[k] = vs
+	}
+	return ret
+}
+
+// ListCurrenciesResp Response when listing currencies
+type ListCurrenciesResp struct {
+	Currencies      []*Currency      `json:"Currencies"`
+	MetaInformation *MetaInformation `json:"MetaInformation"`
+}
+
+// ListCurrencies lists currencies
+func (c *Client) ListCurrencies(ctx context.Context, p *CurrencyQueryParams) (*ListCurrenciesResp, error) {
+	resp := &ListCurrenciesResp{}
+
+	var vals url.Values
+	if p != nil {
+		vals = p.toValues()
+	}
+
+	err := c.request(ctx, "GET", "currencies", nil, vals, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CurrencyResp Response for single currency
+type CurrencyResp struct {
+	Currency Currency `json:"Currency"`
+}
+
+// GetCurrency gets one currency by code
+func (c *Client) GetCurrency(ctx context.Context, code string) (*Currency, error) {
+
+	resp := &CurrencyResp{}
+
+	err := c.request(ctx, "GET", "currencies/"+url.PathEscape(code), nil, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Currency, nil
+}
+
+// CreateCurrency creates a currency
+func (c *Client) CreateCurrency(ctx context.Context, currency *CreateCurrency) (*Currency, error) {
+	resp := &CurrencyResp{}
+	err := c.request(ctx, "POST", "currencies/", &struct {
+		Currency *CreateCurrency `json:"Currency"`
+	}{
+		Currency: currency,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Currency, nil
+}
+
+// UpdateCurrency updates a currency
+func (c *Client) UpdateCurrency(ctx context.Context, code string, currency *UpdateCurrency) (*Currency, error) {
+	resp := &CurrencyResp{}
+	err := c.request(ctx, "PUT", "currencies/"+url.PathEscape(code), &struct {
+		Currency *UpdateCurrency `json:"Currency"`
+	}{
+		Currency: currency,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Currency, nil
+}
+
+// DeleteCurrency deletes one currency
+func (c *Client) DeleteCurrency(ctx context.Context, code string) error {
+	return c.deleteResource(ctx, "currencies/"+url.PathEscape(code))
+}
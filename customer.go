@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"time"
 )
 
 // A Customer is the payload in the responses from the customer endpoint
@@ -174,6 +175,7 @@ type CustomerQueryParams struct {
 	OrganisationNumber string
 	Phone1             string
 	ZipCode            string
+	LastModified       time.Time
 	Page               int
 	Limit              int
 	Offset             int
@@ -211,6 +213,9 @@ func (p *CustomerQueryParams) toValues() url.Values {
 	if len(p.ZipCode) > 0 {
 		ret["zipcode"] = []string{p.ZipCode}
 	}
+	if !p.LastModified.IsZero() {
+		ret["lastmodified"] = []string{p.LastModified.Format(TimeFormat)}
+	}
 	if p.Limit > 0 {
 		ret["limit"] = []string{fmt.Sprintf("%d", p.Limit)}
 	}
@@ -293,3 +298,50 @@ func (c *Client) UpdateCustomer(ctx context.Context, custNum string, customer *U
 func (c *Client) DeleteCustomer(ctx context.Context, custNum string) error {
 	return c.deleteResource(ctx, "customers/"+custNum)
 }
+
+// CustomerIterator walks through every page of customers
+type CustomerIterator struct {
+	it *pageIterator
+}
+
+// Customers iterates over all customers matching the params, fetching pages as needed
+func (c *Client) Customers(ctx context.Context, p *CustomerQueryParams, opts ...IteratorOption) *CustomerIterator {
+	params := CustomerQueryParams{}
+	if p != nil {
+		params = *p
+	}
+	return &CustomerIterator{it: newPageIterator(ctx, params.Page, func(ctx context.Context, page int) ([]interface{}, *MetaInformation, error) {
+		pageParams := params
+		pageParams.Page = page
+		resp, err := c.ListCustomers(ctx, &pageParams)
+		if err != nil {
+			return nil, nil, err
+		}
+		items := make([]interface{}, len(resp.Customers))
+		for i, o := range resp.Customers {
+			items[i] = o
+		}
+		return items, resp.MetaInformation, nil
+	}, opts)}
+}
+
+// Next advances to the next customer, returning false when done or on error
+func (i *CustomerIterator) Next() bool {
+	return i.it.next()
+}
+
+// Customer is the current customer
+func (i *CustomerIterator) Customer() *Customer {
+	v, _ := i.it.cur.(*Customer)
+	return v
+}
+
+// Err is the error which stopped iteration, if any
+func (i *CustomerIterator) Err() error {
+	return i.it.err
+}
+
+// Close stops iteration early
+func (i *CustomerIterator) Close() {
+	i.it.close()
+}
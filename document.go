@@ -0,0 +1,41 @@
+package fortnox
+
+import (
+	"context"
+	"io"
+	"mime"
+	"net/url"
+	"strconv"
+)
+
+// Document is a binary response, such as a pdf, streamed from fortnox. Close it when done
+type Document struct {
+	io.ReadCloser
+	ContentType string
+	// Filename is taken from the Content-Disposition header, or made up from the document if fortnox doesn't send one
+	Filename string
+	// Size is the content length, -1 if unknown
+	Size int64
+}
+
+// getDocumentStream gets a binary resource without buffering it. Set action if getting it does something, see getStream
+func (c *Client) getDocumentStream(ctx context.Context, resource string, p url.Values, filename string, action bool) (*Document, error) {
+	s, err := c.getStream(ctx, resource, p, action)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &Document{
+		ReadCloser:  s.Body,
+		ContentType: s.Header.Get("Content-Type"),
+		Filename:    filename,
+		Size:        -1,
+	}
+	if _, params, err := mime.ParseMediaType(s.Header.Get("Content-Disposition")); err == nil && params["filename"] != "" {
+		doc.Filename = params["filename"]
+	}
+	if size, err := strconv.ParseInt(s.Header.Get("Content-Length"), 10, 64); err == nil {
+		doc.Size = size
+	}
+	return doc, nil
+}
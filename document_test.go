@@ -0,0 +1,48 @@
+package fortnox
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_PreviewInvoicePDF(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		switch r.URL.Path {
+		case "/invoices/1001/preview":
+			w.Header().Set("Content-Disposition", `inline; filename="Faktura 1001.pdf"`)
+		case "/invoices/1002/preview":
+		default:
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write([]byte("%PDF-1.4"))
+	}))
+	defer ts.Close()
+
+	c := NewClient(WithURLOpts(ts.URL+"/"), WithRateLimitOpts(0, 0, 0))
+
+	doc, err := c.PreviewInvoicePDF(context.Background(), 1001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(doc)
+	doc.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "%PDF-1.4" || doc.ContentType != "application/pdf" || doc.Filename != "Faktura 1001.pdf" || doc.Size != 8 {
+		t.Fatalf("unexpected document %+v: %q", doc, data)
+	}
+
+	doc, err = c.PreviewInvoicePDF(context.Background(), 1002)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc.Close()
+	if doc.Filename != "invoice-1002.pdf" {
+		t.Fatal("unexpected filename", doc.Filename)
+	}
+}
@@ -0,0 +1,118 @@
+package fortnox
+
+import (
+	"errors"
+	pkgerrors "github.com/pkg/errors"
+	"net/http"
+	"sync"
+)
+
+// Sentinel errors which a FnoxError can be matched against with errors.Is, or the Is* helpers
+var (
+	ErrNotFound      = errors.New("fortnox: not found")
+	ErrUnauthorized  = errors.New("fortnox: unauthorized")
+	ErrForbidden     = errors.New("fortnox: forbidden")
+	ErrRateLimited   = errors.New("fortnox: rate limited")
+	ErrValidation    = errors.New("fortnox: validation failed")
+	ErrAlreadyBooked = errors.New("fortnox: document already booked")
+	ErrServer        = errors.New("fortnox: server error")
+)
+
+var (
+	errorCodesMu sync.RWMutex
+	// fortnox's numeric error codes, see https://developer.fortnox.se/general/errors/
+	errorCodes = map[int]error{
+		2000310: ErrUnauthorized, // invalid client secret
+		2000311: ErrUnauthorized, // invalid access token
+		2000663: ErrForbidden,    // no access to scope
+		2000106: ErrValidation,   // value must be alphanumeric
+		2000108: ErrValidation,   // value must be numeric
+		2000134: ErrValidation,   // value must be boolean
+		2000357: ErrValidation,   // required value missing
+		2000588: ErrAlreadyBooked,
+	}
+)
+
+// RegisterErrorCode maps a fortnox error code to one of the sentinel errors, for codes missing from the built in table.
+// The table is global, so call it during start up, before any client is in use
+func RegisterErrorCode(code int, sentinel error) {
+	errorCodesMu.Lock()
+	defer errorCodesMu.Unlock()
+	errorCodes[code] = sentinel
+}
+
+// Is reports whether the error matches a sentinel, by error code or http status
+func (f FnoxError) Is(target error) bool {
+	errorCodesMu.RLock()
+	byCode, ok := errorCodes[f.Code]
+	errorCodesMu.RUnlock()
+	if ok && byCode == target {
+		return true
+	}
+
+	switch target {
+	case ErrNotFound:
+		return f.HTTPStatus == http.StatusNotFound
+	case ErrUnauthorized:
+		return f.HTTPStatus == http.StatusUnauthorized
+	case ErrForbidden:
+		return f.HTTPStatus == http.StatusForbidden
+	case ErrRateLimited:
+		return f.HTTPStatus == http.StatusTooManyRequests
+	case ErrValidation:
+		return f.HTTPStatus == http.StatusBadRequest && (!ok || byCode == ErrValidation || byCode == ErrAlreadyBooked)
+	case ErrServer:
+		return f.HTTPStatus >= 500
+	}
+	return false
+}
+
+// Is reports whether the token endpoint rejected our credentials
+func (o OAuthError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return o.HTTPStatus == http.StatusUnauthorized || o.Code == "invalid_grant" || o.Code == "invalid_client"
+	case ErrRateLimited:
+		return o.HTTPStatus == http.StatusTooManyRequests
+	case ErrServer:
+		return o.HTTPStatus >= 500
+	}
+	return false
+}
+
+// IsNotFound reports whether the resource doesn't exist
+func IsNotFound(err error) bool { return is(err, ErrNotFound) }
+
+// IsUnauthorized reports whether the credentials were rejected
+func IsUnauthorized(err error) bool { return is(err, ErrUnauthorized) }
+
+// IsForbidden reports whether the credentials lack access to the resource
+func IsForbidden(err error) bool { return is(err, ErrForbidden) }
+
+// IsRateLimited reports whether the request was rejected for exceeding the rate limit
+func IsRateLimited(err error) bool { return is(err, ErrRateLimited) }
+
+// IsValidation reports whether the request was rejected for bad input
+func IsValidation(err error) bool { return is(err, ErrValidation) }
+
+// IsAlreadyBooked reports whether the document couldn't be changed since it's booked
+func IsAlreadyBooked(err error) bool { return is(err, ErrAlreadyBooked) }
+
+// IsServerError reports whether fortnox failed with a 5xx
+func IsServerError(err error) bool { return is(err, ErrServer) }
+
+// IsTemporary reports whether the request may succeed if retried later
+func IsTemporary(err error) bool {
+	return IsRateLimited(err) || IsServerError(err)
+}
+
+func is(err error, target error) bool {
+	if errors.Is(err, target) {
+		return true
+	}
+	// github.com/pkg/errors before 0.9 doesn't support Unwrap
+	if cause := pkgerrors.Cause(err); cause != err {
+		return errors.Is(cause, target)
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package fortnox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	pkgerrors "github.com/pkg/errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFnoxError_Is(t *testing.T) {
+	notFound := FnoxError{HTTPStatus: 404, Code: 2000433, Message: "Kan inte hitta kunden"}
+	if !IsNotFound(notFound) || IsValidation(notFound) {
+		t.Fatal("expected not found only")
+	}
+	if !errors.Is(pkgerrors.Wrap(notFound, "wrapped"), ErrNotFound) {
+		t.Fatal("expected wrapped error to match")
+	}
+
+	booked := FnoxError{HTTPStatus: 400, Code: 2000588}
+	if !IsAlreadyBooked(booked) || !IsValidation(booked) {
+		t.Fatal("expected already booked validation error")
+	}
+
+	unauthorized := FnoxError{HTTPStatus: 400, Code: 2000311}
+	if !IsUnauthorized(unauthorized) || IsValidation(unauthorized) {
+		t.Fatal("expected unauthorized by code")
+	}
+
+	if !IsTemporary(FnoxError{HTTPStatus: 429}) || !IsTemporary(FnoxError{HTTPStatus: 503}) {
+		t.Fatal("expected temporary")
+	}
+
+	var fErr FnoxError
+	if !errors.As(pkgerrors.Wrap(notFound, "wrapped"), &fErr) || fErr.Code != 2000433 {
+		t.Fatal("expected errors.As to find FnoxError")
+	}
+
+	RegisterErrorCode(1234, ErrAlreadyBooked)
+	defer func() {
+		errorCodesMu.Lock()
+		delete(errorCodes, 1234)
+		errorCodesMu.Unlock()
+	}()
+	if !IsAlreadyBooked(FnoxError{HTTPStatus: 400, Code: 1234}) {
+		t.Fatal("expected registered code to match")
+	}
+
+	if !IsUnauthorized(pkgerrors.Wrap(OAuthError{HTTPStatus: 400, Code: "invalid_grant"}, "failed to refresh token")) {
+		t.Fatal("expected invalid grant to be unauthorized")
+	}
+}
+
+func TestRequest_KeepsErrorBody(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+		fmt.Fprint(w, `{"ErrorInformation":{"error":1,"message":"Kan inte hitta artikeln","code":2000428}}`)
+	}))
+	defer ts.Close()
+
+	c := NewClient(WithURLOpts(ts.URL+"/"), WithRateLimitOpts(0, 0, 0))
+	_, err := c.GetArticle(context.Background(), "1")
+	if !IsNotFound(err) {
+		t.Fatal("expected not found, got", err)
+	}
+	fErr := err.(FnoxError)
+	if fErr.Code != 2000428 || fErr.Message != "Kan inte hitta artikeln" {
+		t.Fatalf("unexpected error %+v", fErr)
+	}
+	if fErr.Body == "" {
+		t.Fatal("expected raw body")
+	}
+}
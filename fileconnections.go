@@ -0,0 +1,236 @@
+package fortnox
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"io"
+	"net/url"
+	"strconv"
+)
+
+// SupplierInvoiceFileConnection links a file in the archive or inbox to a supplier invoice
+type SupplierInvoiceFileConnection struct {
+	URL                   string `json:"@url"`
+	FileID                string `json:"FileId"`
+	Name                  string `json:"Name"`
+	SupplierInvoiceNumber string `json:"SupplierInvoiceNumber"`
+	SupplierName          string `json:"SupplierName"`
+}
+
+// ListSupplierInvoiceFileConnectionsResp Response when listing supplier invoice file connections
+type ListSupplierInvoiceFileConnectionsResp struct {
+	SupplierInvoiceFileConnections []*SupplierInvoiceFileConnection `json:"SupplierInvoiceFileConnections"`
+	MetaInformation                *MetaInformation                 `json:"MetaInformation"`
+}
+
+// SupplierInvoiceFileConnectionResp Response for single supplier invoice file connection
+type SupplierInvoiceFileConnectionResp struct {
+	SupplierInvoiceFileConnection SupplierInvoiceFileConnection `json:"SupplierInvoiceFileConnection"`
+}
+
+// ListSupplierInvoiceFileConnections lists the files connected to a supplier invoice
+func (c *Client) ListSupplierInvoiceFileConnections(ctx context.Context, givenNumber int) (*ListSupplierInvoiceFileConnectionsResp, error) {
+	resp := &ListSupplierInvoiceFileConnectionsResp{}
+
+	vals := url.Values{"supplierinvoicenumber": {strconv.Itoa(givenNumber)}}
+	err := c.request(ctx, "GET", "supplierinvoicefileconnections", nil, vals, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CreateSupplierInvoiceFileConnection connects a file to a supplier invoice
+func (c *Client) CreateSupplierInvoiceFileConnection(ctx context.Context, fileID string, givenNumber int) (*SupplierInvoiceFileConnection, error) {
+	resp := &SupplierInvoiceFileConnectionResp{}
+
+	req := &struct {
+		SupplierInvoiceFileConnection struct {
+			FileID                string `json:"FileId"`
+			SupplierInvoiceNumber string `json:"SupplierInvoiceNumber"`
+		} `json:"SupplierInvoiceFileConnection"`
+	}{}
+	req.SupplierInvoiceFileConnection.FileID = fileID
+	req.SupplierInvoiceFileConnection.SupplierInvoiceNumber = strconv.Itoa(givenNumber)
+	err := c.request(ctx, "POST", "supplierinvoicefileconnections/", req, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.SupplierInvoiceFileConnection, nil
+}
+
+// DeleteSupplierInvoiceFileConnection removes a file from its supplier invoice
+func (c *Client) DeleteSupplierInvoiceFileConnection(ctx context.Context, fileID string) error {
+	return c.deleteResource(ctx, "supplierinvoicefileconnections/"+url.PathEscape(fileID))
+}
+
+// VoucherFileConnection links a file in the archive or inbox to a voucher
+type VoucherFileConnection struct {
+	URL                string `json:"@url"`
+	FileID             string `json:"FileId"`
+	VoucherDescription string `json:"VoucherDescription"`
+	VoucherNumber      Intish `json:"VoucherNumber"`
+	VoucherSeries      string `json:"VoucherSeries"`
+	VoucherYear        Intish `json:"VoucherYear"`
+}
+
+// ListVoucherFileConnectionsResp Response when listing voucher file connections
+type ListVoucherFileConnectionsResp struct {
+	VoucherFileConnections []*VoucherFileConnection `json:"VoucherFileConnections"`
+	MetaInformation        *MetaInformation         `json:"MetaInformation"`
+}
+
+// VoucherFileConnectionResp Response for single voucher file connection
+type VoucherFileConnectionResp struct {
+	VoucherFileConnection VoucherFileConnection `json:"VoucherFileConnection"`
+}
+
+// ListVoucherFileConnections lists voucher file connections. financialYear is the id of the financial year, 0 for the current one
+func (c *Client) ListVoucherFileConnections(ctx context.Context, financialYear int) (*ListVoucherFileConnectionsResp, error) {
+	resp := &ListVoucherFileConnectionsResp{}
+
+	var vals url.Values
+	if financialYear > 0 {
+		vals = url.Values{"financialyear": {strconv.Itoa(financialYear)}}
+	}
+	err := c.request(ctx, "GET", "voucherfileconnections", nil, vals, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CreateVoucherFileConnection connects a file to a voucher. financialYear is the id of the financial year, 0 for the current one
+func (c *Client) CreateVoucherFileConnection(ctx context.Context, fileID string, series string, number int, financialYear int) (*VoucherFileConnection, error) {
+	resp := &VoucherFileConnectionResp{}
+
+	req := &struct {
+		VoucherFileConnection struct {
+			FileID        string `json:"FileId"`
+			VoucherNumber string `json:"VoucherNumber"`
+			VoucherSeries string `json:"VoucherSeries"`
+		} `json:"VoucherFileConnection"`
+	}{}
+	req.VoucherFileConnection.FileID = fileID
+	req.VoucherFileConnection.VoucherNumber = strconv.Itoa(number)
+	req.VoucherFileConnection.VoucherSeries = series
+
+	var vals url.Values
+	if financialYear > 0 {
+		vals = url.Values{"financialyear": {strconv.Itoa(financialYear)}}
+	}
+	err := c.request(ctx, "POST", "voucherfileconnections/", req, vals, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.VoucherFileConnection, nil
+}
+
+// DeleteVoucherFileConnection removes a file from its voucher
+func (c *Client) DeleteVoucherFileConnection(ctx context.Context, fileID string) error {
+	return c.deleteResource(ctx, "voucherfileconnections/"+url.PathEscape(fileID))
+}
+
+// ArticleFileConnection links a file in the archive to an article
+type ArticleFileConnection struct {
+	URL           string `json:"@url"`
+	ArticleNumber string `json:"ArticleNumber"`
+	FileID        string `json:"FileId"`
+}
+
+// ListArticleFileConnectionsResp Response when listing article file connections
+type ListArticleFileConnectionsResp struct {
+	ArticleFileConnections []*ArticleFileConnection `json:"ArticleFileConnections"`
+	MetaInformation        *MetaInformation         `json:"MetaInformation"`
+}
+
+// ArticleFileConnectionResp Response for single article file connection
+type ArticleFileConnectionResp struct {
+	ArticleFileConnection ArticleFileConnection `json:"ArticleFileConnection"`
+}
+
+// ListArticleFileConnections lists article file connections
+func (c *Client) ListArticleFileConnections(ctx context.Context) (*ListArticleFileConnectionsResp, error) {
+	resp := &ListArticleFileConnectionsResp{}
+
+	err := c.request(ctx, "GET", "articlefileconnections", nil, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CreateArticleFileConnection connects a file to an article
+func (c *Client) CreateArticleFileConnection(ctx context.Context, fileID string, articleNum string) (*ArticleFileConnection, error) {
+	resp := &ArticleFileConnectionResp{}
+
+	req := &struct {
+		ArticleFileConnection struct {
+			ArticleNumber string `json:"ArticleNumber"`
+			FileID        string `json:"FileId"`
+		} `json:"ArticleFileConnection"`
+	}{}
+	req.ArticleFileConnection.ArticleNumber = articleNum
+	req.ArticleFileConnection.FileID = fileID
+	err := c.request(ctx, "POST", "articlefileconnections/", req, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.ArticleFileConnection, nil
+}
+
+// DeleteArticleFileConnection removes a file from its article
+func (c *Client) DeleteArticleFileConnection(ctx context.Context, fileID string) error {
+	return c.deleteResource(ctx, "articlefileconnections/"+url.PathEscape(fileID))
+}
+
+// AttachFileToSupplierInvoice uploads a file to the supplier invoice inbox and connects it to a supplier invoice
+func (c *Client) AttachFileToSupplierInvoice(ctx context.Context, givenNumber int, filename string, r io.Reader) (*SupplierInvoiceFileConnection, error) {
+	f, err := c.UploadInboxFile(ctx, InboxSupplierInvoices, filename, r)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := c.CreateSupplierInvoiceFileConnection(ctx, f.ID, givenNumber)
+	if err != nil {
+		return nil, discardFile(ctx, c.DeleteInboxFile, f.ID, err)
+	}
+	return conn, nil
+}
+
+// AttachFileToVoucher uploads a file to the voucher inbox and connects it to a voucher.
+// financialYear is the id of the financial year, 0 for the current one
+func (c *Client) AttachFileToVoucher(ctx context.Context, series string, number int, financialYear int, filename string, r io.Reader) (*VoucherFileConnection, error) {
+	f, err := c.UploadInboxFile(ctx, InboxVouchers, filename, r)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := c.CreateVoucherFileConnection(ctx, f.ID, series, number, financialYear)
+	if err != nil {
+		return nil, discardFile(ctx, c.DeleteInboxFile, f.ID, err)
+	}
+	return conn, nil
+}
+
+// AttachFileToArticle uploads a file to the root of the archive and connects it to an article
+func (c *Client) AttachFileToArticle(ctx context.Context, articleNum string, filename string, r io.Reader) (*ArticleFileConnection, error) {
+	f, err := c.UploadArchiveFile(ctx, "", filename, r)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := c.CreateArticleFileConnection(ctx, f.ID, articleNum)
+	if err != nil {
+		return nil, discardFile(ctx, c.DeleteArchiveFile, f.ID, err)
+	}
+	return conn, nil
+}
+
+// discardFile deletes an uploaded file which couldn't be connected, so it doesn't linger. err is the connection error
+func discardFile(ctx context.Context, del func(ctx context.Context, id string) error, fileID string, err error) error {
+	if delErr := del(ctx, fileID); delErr != nil {
+		return errors.Wrapf(err, "uploaded file %s was left behind (%v)", fileID, delErr)
+	}
+	return err
+}
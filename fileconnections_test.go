@@ -0,0 +1,137 @@
+package fortnox
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestClient_VoucherFileConnections(t *testing.T) {
+	api := newStubAPI(t, map[string]string{
+		"GET voucherfileconnections":           `{"VoucherFileConnections":[{"FileId":"file-1","VoucherNumber":"3","VoucherSeries":"A","VoucherYear":"2"}],"MetaInformation":{"@CurrentPage":1,"@TotalPages":1,"@TotalResources":1}}`,
+		"POST voucherfileconnections/":         `{"VoucherFileConnection":{"FileId":"file-2","VoucherNumber":"3","VoucherSeries":"A","VoucherYear":"2"}}`,
+		"POST inbox":                           `{"File":{"Id":"file-2","Name":"receipt.pdf"}}`,
+		"DELETE voucherfileconnections/file-1": ``,
+	})
+	defer api.Close()
+	c := api.client()
+	ctx := context.Background()
+
+	list, err := c.ListVoucherFileConnections(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.VoucherFileConnections) != 1 || list.VoucherFileConnections[0].VoucherNumber != 3 {
+		t.Fatalf("unexpected connections %+v", list.VoucherFileConnections)
+	}
+	if q := api.received("GET voucherfileconnections")[0].Query; q.Get("financialyear") != "2" {
+		t.Fatal("unexpected query", q)
+	}
+	if _, err := c.ListVoucherFileConnections(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if q := api.received("GET voucherfileconnections")[1].Query; q.Get("financialyear") != "" {
+		t.Fatal("expected the current financial year, got", q)
+	}
+
+	conn, err := c.AttachFileToVoucher(ctx, "A", 3, 2, "receipt.pdf", strings.NewReader("%PDF"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conn.FileID != "file-2" || conn.VoucherYear != 2 {
+		t.Fatalf("unexpected connection %+v", conn)
+	}
+	if q := api.received("POST inbox")[0].Query; q.Get("path") != InboxVouchers {
+		t.Fatal("unexpected inbox folder", q)
+	}
+	req := api.received("POST voucherfileconnections/")[0]
+	if req.Body != `{"VoucherFileConnection":{"FileId":"file-2","VoucherNumber":"3","VoucherSeries":"A"}}`+"\n" {
+		t.Fatal("unexpected body", req.Body)
+	}
+	if req.Query.Get("financialyear") != "2" {
+		t.Fatal("unexpected query", req.Query)
+	}
+
+	if err := c.DeleteVoucherFileConnection(ctx, "file-1"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClient_AttachFileToVoucherCleansUp(t *testing.T) {
+	api := newStubAPI(t, map[string]string{
+		"POST inbox":                   `{"File":{"Id":"file-2","Name":"receipt.pdf"}}`,
+		"POST voucherfileconnections/": `{}`,
+		"DELETE inbox/file-2":          ``,
+	})
+	defer api.Close()
+	api.fail("POST voucherfileconnections/", http.StatusNotFound)
+
+	_, err := api.client().AttachFileToVoucher(context.Background(), "A", 3, 0, "receipt.pdf", strings.NewReader("%PDF"))
+	if !IsNotFound(err) {
+		t.Fatal("expected not found, got", err)
+	}
+	if n := len(api.received("DELETE inbox/file-2")); n != 1 {
+		t.Fatal("expected the uploaded file to be deleted, got deletes:", n)
+	}
+}
+
+func TestClient_ArticleFileConnections(t *testing.T) {
+	api := newStubAPI(t, map[string]string{
+		"GET articlefileconnections":           `{"ArticleFileConnections":[{"ArticleNumber":"10","FileId":"file-1"}],"MetaInformation":{"@CurrentPage":1,"@TotalPages":1,"@TotalResources":1}}`,
+		"POST articlefileconnections/":         `{"ArticleFileConnection":{"ArticleNumber":"10","FileId":"file-2"}}`,
+		"POST archive":                         `{"File":{"Id":"file-2","Name":"photo.jpg"}}`,
+		"DELETE articlefileconnections/file-1": ``,
+	})
+	defer api.Close()
+	c := api.client()
+	ctx := context.Background()
+
+	list, err := c.ListArticleFileConnections(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.ArticleFileConnections) != 1 || list.ArticleFileConnections[0].ArticleNumber != "10" {
+		t.Fatalf("unexpected connections %+v", list.ArticleFileConnections)
+	}
+
+	conn, err := c.AttachFileToArticle(ctx, "10", "photo.jpg", strings.NewReader("jpeg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conn.FileID != "file-2" {
+		t.Fatalf("unexpected connection %+v", conn)
+	}
+	if q := api.received("POST archive")[0].Query; q.Get("path") != "" {
+		t.Fatal("expected the root folder, got", q)
+	}
+	if body := api.received("POST articlefileconnections/")[0].Body; body != `{"ArticleFileConnection":{"ArticleNumber":"10","FileId":"file-2"}}`+"\n" {
+		t.Fatal("unexpected body", body)
+	}
+
+	if err := c.DeleteArticleFileConnection(ctx, "file-1"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClient_AttachFileToArticleCleansUp(t *testing.T) {
+	api := newStubAPI(t, map[string]string{
+		"POST archive":                 `{"File":{"Id":"file-2","Name":"photo.jpg"}}`,
+		"POST articlefileconnections/": `{}`,
+		"DELETE archive/file-2":        ``,
+	})
+	defer api.Close()
+	api.fail("POST articlefileconnections/", http.StatusBadRequest)
+	api.fail("DELETE archive/file-2", http.StatusInternalServerError)
+
+	_, err := api.client().AttachFileToArticle(context.Background(), "10", "photo.jpg", strings.NewReader("jpeg"))
+	if !IsValidation(err) {
+		t.Fatal("expected validation error, got", err)
+	}
+	if !strings.Contains(err.Error(), "file-2 was left behind") {
+		t.Fatal("expected the leftover file to be reported, got", err)
+	}
+	if n := len(api.received("DELETE archive/file-2")); n == 0 {
+		t.Fatal("expected the uploaded file to be deleted")
+	}
+}
@@ -0,0 +1,127 @@
+package fortnox
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/url"
+	"strconv"
+)
+
+// FinancialYear data type
+type FinancialYear struct {
+	URL              string `json:"@url"`
+	ID               int    `json:"Id"`
+	AccountChartType string `json:"AccountChartType"`
+	AccountingMethod string `json:"AccountingMethod"`
+	FromDate         Date   `json:"FromDate"`
+	ToDate           Date   `json:"ToDate"`
+}
+
+// CreateFinancialYear payload for creating financial years.
+// AccountingMethod is either ACCRUAL or CASH
+type CreateFinancialYear struct {
+	AccountChartType *string `json:"AccountChartType,omitempty"`
+	AccountingMethod *string `json:"AccountingMethod,omitempty"`
+	FromDate         *string `json:"FromDate,omitempty"`
+	ToDate           *string `json:"ToDate,omitempty"`
+}
+
+// FinancialYearQueryParams when searching financial years
+type FinancialYearQueryParams struct {
+	// Date finds the financial year containing the date
+	Date   string
+	Page   int
+	Limit  int
+	Offset int
+	Extra  map[string][]string
+}
+
+func (p FinancialYearQueryParams) toValues() url.Values {
+
+	ret := make(url.Values)
+
+	if len(p.Date) > 0 {
+		ret["date"] = []string{p.Date}
+	}
+	if p.Limit > 0 {
+		ret["limit"] = []string{fmt.Sprintf("%d", p.Limit)}
+	}
+	if p.Offset > 0 {
+		ret["offset"] = []string{fmt.Sprintf("%d", p.Offset)}
+	}
+	if p.Page > 0 {
+		ret["page"] = []string{fmt.Sprintf("%d", p.Page)}
+	}
+	for k, vs := range p.Extra {
+		ret[k] = vs
+	}
+	return ret
+}
+
+// ListFinancialYearsResp Response when listing financial years
+type ListFinancialYearsResp struct {
+	FinancialYears  []*FinancialYear `json:"FinancialYears"`
+	MetaInformation *MetaInformation `json:"MetaInformation"`
+}
+
+// ListFinancialYears lists financial years
+func (c *Client) ListFinancialYears(ctx context.Context, p *FinancialYearQueryParams) (*ListFinancialYearsResp, error) {
+	resp := &ListFinancialYearsResp{}
+
+	var vals url.Values
+	if p != nil {
+		vals = p.toValues()
+	}
+
+	err := c.request(ctx, "GET", "financialyears", nil, vals, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// FinancialYearResp Response for single financial year
+type FinancialYearResp struct {
+	FinancialYear FinancialYear `json:"FinancialYear"`
+}
+
+// GetFinancialYear gets one financial year by id
+func (c *Client) GetFinancialYear(ctx context.Context, id int) (*FinancialYear, error) {
+
+	resp := &FinancialYearResp{}
+
+	err := c.request(ctx, "GET", "financialyears/"+strconv.Itoa(id), nil, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.FinancialYear, nil
+}
+
+// GetFinancialYearByDate gets the financial year containing date (YYYY-MM-DD). Matches ErrNotFound if there is none
+func (c *Client) GetFinancialYearByDate(ctx context.Context, date string) (*FinancialYear, error) {
+	resp, err := c.ListFinancialYears(ctx, &FinancialYearQueryParams{Date: date})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.FinancialYears) == 0 {
+		return nil, errors.Wrapf(ErrNotFound, "no financial year contains %s", date)
+	}
+	return resp.FinancialYears[0], nil
+}
+
+// CreateFinancialYear creates a financial year
+func (c *Client) CreateFinancialYear(ctx context.Context, year *CreateFinancialYear) (*FinancialYear, error) {
+	resp := &FinancialYearResp{}
+	err := c.request(ctx, "POST", "financialyears/", &struct {
+		FinancialYear *CreateFinancialYear `json:"FinancialYear"`
+	}{
+		FinancialYear: year,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.FinancialYear, nil
+}
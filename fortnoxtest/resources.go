@@ -0,0 +1,666 @@
+package fortnoxtest
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	dateFormat = "2006-01-02"
+	timeFormat = "2006-01-02 15:04"
+)
+
+// resourceDef describes how the fake treats a resource
+type resourceDef struct {
+	// wrapper is the key single objects are wrapped in, list is the key for lists
+	wrapper, list string
+	// id is the field holding the object's number
+	id string
+	// required fields must be set on create
+	required []string
+	// search lists the fields which can be searched by passing them lowercased as query parameters
+	search    []string
+	filters   map[string]func(obj map[string]interface{}, today string) bool
+	deletable bool
+	actions   map[string]action
+	// prepare fills in and checks fields on create and update
+	prepare func(s *Server, obj map[string]interface{}) error
+}
+
+type action struct {
+	method string
+	do     func(s *Server, obj map[string]interface{}) (int, interface{}, error)
+}
+
+var resources map[Resource]resourceDef
+
+func init() {
+	resources = map[Resource]resourceDef{
+		Customers: {
+			wrapper:  "Customer",
+			list:     "Customers",
+			id:       "CustomerNumber",
+			required: []string{"Name"},
+			search:   []string{"City", "CustomerNumber", "Email", "Name", "OrganisationNumber", "Phone", "ZipCode"},
+			filters: map[string]func(map[string]interface{}, string) bool{
+				"active":   func(obj map[string]interface{}, _ string) bool { return isTrue(obj["Active"]) },
+				"inactive": func(obj map[string]interface{}, _ string) bool { return !isTrue(obj["Active"]) },
+			},
+			deletable: true,
+			prepare:   prepareCustomer,
+		},
+		Articles: {
+			wrapper:  "Article",
+			list:     "Articles",
+			id:       "ArticleNumber",
+			required: []string{"Description"},
+			search:   []string{"ArticleNumber", "Description", "EAN", "Manufacturer", "ManufacturerArticleNumber", "SupplierNumber"},
+			filters: map[string]func(map[string]interface{}, string) bool{
+				"active":   func(obj map[string]interface{}, _ string) bool { return isTrue(obj["Active"]) },
+				"inactive": func(obj map[string]interface{}, _ string) bool { return !isTrue(obj["Active"]) },
+			},
+			deletable: true,
+			prepare:   prepareArticle,
+		},
+		Orders: {
+			wrapper:  "Order",
+			list:     "Orders",
+			id:       "DocumentNumber",
+			required: []string{"CustomerNumber"},
+			search:   []string{"CustomerName", "CustomerNumber", "DocumentNumber", "ExternalInvoiceReference1", "ExternalInvoiceReference2", "OurReference", "YourReference"},
+			filters: map[string]func(map[string]interface{}, string) bool{
+				"cancelled":      func(obj map[string]interface{}, _ string) bool { return isTrue(obj["Cancelled"]) },
+				"invoicecreated": func(obj map[string]interface{}, _ string) bool { return isSet(obj["InvoiceReference"]) },
+				"invoicenotcreated": func(obj map[string]interface{}, _ string) bool {
+					return !isTrue(obj["Cancelled"]) && !isSet(obj["InvoiceReference"])
+				},
+			},
+			actions: map[string]action{
+				"createinvoice": {"PUT", createInvoiceFromOrder},
+				"cancel":        {"PUT", cancelOrder},
+				"email":         {"GET", markSent(Orders)},
+				"print":         {"GET", printDocument(Orders, true)},
+				"preview":       {"GET", printDocument(Orders, false)},
+			},
+			prepare: prepareOrder,
+		},
+		Invoices: {
+			wrapper:  "Invoice",
+			list:     "Invoices",
+			id:       "DocumentNumber",
+			required: []string{"CustomerNumber"},
+			search:   []string{"CustomerName", "CustomerNumber", "DocumentNumber", "ExternalInvoiceReference1", "ExternalInvoiceReference2", "OCR", "OurReference", "YourReference"},
+			filters: map[string]func(map[string]interface{}, string) bool{
+				"cancelled": func(obj map[string]interface{}, _ string) bool { return isTrue(obj["Cancelled"]) },
+				"fullypaid": func(obj map[string]interface{}, _ string) bool {
+					return !isTrue(obj["Cancelled"]) && isTrue(obj["Booked"]) && toFloat(obj["Balance"]) == 0
+				},
+				"unpaid": func(obj map[string]interface{}, _ string) bool {
+					return !isTrue(obj["Cancelled"]) && toFloat(obj["Balance"]) != 0
+				},
+				"unpaidoverdue": func(obj map[string]interface{}, today string) bool {
+					return !isTrue(obj["Cancelled"]) && toFloat(obj["Balance"]) != 0 && fmt.Sprint(obj["DueDate"]) < today
+				},
+				"unbooked": func(obj map[string]interface{}, _ string) bool {
+					return !isTrue(obj["Cancelled"]) && !isTrue(obj["Booked"])
+				},
+			},
+			actions: map[string]action{
+				"bookkeep":       {"PUT", bookkeepInvoice},
+				"cancel":         {"PUT", cancelInvoice},
+				"credit":         {"PUT", creditInvoice},
+				"email":          {"GET", markSent(Invoices)},
+				"externalprint":  {"PUT", markSent(Invoices)},
+				"warehouseready": {"PUT", respond(Invoices)},
+				"print":          {"GET", printDocument(Invoices, true)},
+				"preview":        {"GET", printDocument(Invoices, false)},
+			},
+			prepare: prepareInvoice,
+		},
+		Labels: {
+			wrapper:   "Label",
+			list:      "Labels",
+			id:        "Id",
+			required:  []string{"Description"},
+			search:    []string{"Description"},
+			deletable: true,
+			prepare:   prepareLabel,
+		},
+	}
+}
+
+// store holds the objects of one resource, by number
+type store struct {
+	objects map[string]map[string]interface{}
+	// modified is when each object was last created or changed, for the lastmodified filter
+	modified map[string]time.Time
+	next     int
+}
+
+func newStore() *store {
+	return &store{objects: map[string]map[string]interface{}{}, modified: map[string]time.Time{}}
+}
+
+// nextID assigns the next free number
+func (st *store) nextID() string {
+	for {
+		st.next++
+		id := strconv.Itoa(st.next)
+		if _, ok := st.objects[id]; !ok {
+			return id
+		}
+	}
+}
+
+// stockholm is the time zone fortnox reads lastmodified in
+var stockholm = func() *time.Location {
+	loc, err := time.LoadLocation("Europe/Stockholm")
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}()
+
+// sorted lists the objects with numeric ids first, in order, then the rest alphabetically
+func (st *store) sorted() []map[string]interface{} {
+	ids := make([]string, 0, len(st.objects))
+	for id := range st.objects {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, aErr := strconv.Atoi(ids[i])
+		b, bErr := strconv.Atoi(ids[j])
+		switch {
+		case aErr == nil && bErr == nil:
+			return a < b
+		case aErr == nil:
+			return true
+		case bErr == nil:
+			return false
+		}
+		return ids[i] < ids[j]
+	})
+	objs := make([]map[string]interface{}, len(ids))
+	for i, id := range ids {
+		objs[i] = st.objects[id]
+	}
+	return objs
+}
+
+func (s *Server) lookup(r Resource, id string) (map[string]interface{}, error) {
+	obj, ok := s.stores[r].objects[id]
+	if !ok {
+		return nil, notFound("%s %s not found", resources[r].wrapper, id)
+	}
+	return obj, nil
+}
+
+func (s *Server) list(r Resource, q url.Values) (int, interface{}, error) {
+	def := resources[r]
+
+	var match func(map[string]interface{}, string) bool
+	if filter := q.Get("filter"); filter != "" {
+		var ok bool
+		if match, ok = def.filters[strings.ToLower(filter)]; !ok {
+			return 0, nil, invalid("invalid filter %s", filter)
+		}
+	}
+
+	var since time.Time
+	if v := q.Get("lastmodified"); v != "" {
+		var err error
+		if since, err = time.ParseInLocation(timeFormat, v, stockholm); err != nil {
+			return 0, nil, invalid("invalid lastmodified %s", v)
+		}
+	}
+
+	limit, page, offset := 100, 1, 0
+	for key, dst := range map[string]*int{"limit": &limit, "page": &page, "offset": &offset} {
+		if v := q.Get(key); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				return 0, nil, errorf(http.StatusBadRequest, codeNumeric, "%s must be numeric", key)
+			}
+			*dst = n
+		}
+	}
+	if limit < 1 || limit > 500 {
+		return 0, nil, invalid("limit must be between 1 and 500")
+	}
+	if page < 1 {
+		page = 1
+	}
+
+	today := s.now().Format(dateFormat)
+	st := s.stores[r]
+	var matched []map[string]interface{}
+	for _, obj := range st.sorted() {
+		if !since.IsZero() && st.modified[fmt.Sprint(obj[def.id])].Before(since) {
+			continue
+		}
+		if match != nil && !match(obj, today) {
+			continue
+		}
+		if !searchMatches(def.search, obj, q) {
+			continue
+		}
+		matched = append(matched, obj)
+	}
+
+	items := []map[string]interface{}{}
+	for i := offset + (page-1)*limit; i < len(matched) && len(items) < limit; i++ {
+		items = append(items, copyObject(matched[i]))
+	}
+
+	totalPages := (len(matched) + limit - 1) / limit
+	if totalPages == 0 {
+		totalPages = 1
+	}
+	return http.StatusOK, map[string]interface{}{
+		def.list: items,
+		"MetaInformation": map[string]interface{}{
+			"@CurrentPage":    page,
+			"@TotalPages":     totalPages,
+			"@TotalResources": len(matched),
+		},
+	}, nil
+}
+
+// searchMatches does a case insensitive substring match for each searchable field given in the query
+func searchMatches(fields []string, obj map[string]interface{}, q url.Values) bool {
+	for _, field := range fields {
+		want := q.Get(strings.ToLower(field))
+		if want == "" {
+			continue
+		}
+		got, ok := obj[field]
+		if !ok || got == nil {
+			return false
+		}
+		if !strings.Contains(strings.ToLower(fmt.Sprint(got)), strings.ToLower(want)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) create(r Resource, fields map[string]interface{}) (map[string]interface{}, error) {
+	def := resources[r]
+	st := s.stores[r]
+
+	if err := checkRequired(def, fields); err != nil {
+		return nil, err
+	}
+
+	// numbers aren't used up by failed creates
+	next := st.next
+	id := ""
+	if v, ok := fields[def.id]; ok && isSet(v) && r != Labels {
+		id = fmt.Sprint(v)
+		if _, exists := st.objects[id]; exists {
+			return nil, invalid("%s %s is already in use", def.id, id)
+		}
+	} else {
+		id = st.nextID()
+	}
+
+	obj := fields
+	if r == Labels {
+		n, _ := strconv.Atoi(id)
+		obj[def.id] = n
+	} else {
+		obj[def.id] = id
+	}
+	obj["@url"] = s.BaseURL() + string(r) + "/" + url.PathEscape(id)
+
+	if err := def.prepare(s, obj); err != nil {
+		st.next = next
+		return nil, err
+	}
+	st.objects[id] = obj
+	st.modified[id] = s.now()
+	return obj, nil
+}
+
+func (s *Server) update(r Resource, id string, fields map[string]interface{}) (map[string]interface{}, error) {
+	def := resources[r]
+	existing, err := s.lookup(r, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch r {
+	case Orders:
+		if isTrue(existing["Cancelled"]) {
+			return nil, invalid("order %s is cancelled", id)
+		}
+		if isSet(existing["InvoiceReference"]) {
+			return nil, invalid("order %s has been invoiced", id)
+		}
+	case Invoices:
+		if isTrue(existing["Booked"]) {
+			return nil, errorf(http.StatusBadRequest, codeAlreadyBooked, "invoice %s is booked and can't be changed", id)
+		}
+		if isTrue(existing["Cancelled"]) {
+			return nil, invalid("invoice %s is cancelled", id)
+		}
+	}
+
+	// fields are replaced one by one, rows as a whole
+	obj := copyObject(existing)
+	for k, v := range fields {
+		if k == def.id || k == "@url" {
+			continue
+		}
+		obj[k] = v
+	}
+	// like fortnox, required fields are only checked on create
+	if err := def.prepare(s, obj); err != nil {
+		return nil, err
+	}
+	s.stores[r].objects[id] = obj
+	s.stores[r].modified[id] = s.now()
+	return obj, nil
+}
+
+func (s *Server) delete(r Resource, id string) error {
+	if _, err := s.lookup(r, id); err != nil {
+		return err
+	}
+	if r == Customers {
+		for _, doc := range []Resource{Orders, Invoices} {
+			for _, obj := range s.stores[doc].objects {
+				if fmt.Sprint(obj["CustomerNumber"]) == id {
+					return invalid("customer %s has %s and can't be deleted", id, doc)
+				}
+			}
+		}
+	}
+	delete(s.stores[r].objects, id)
+	delete(s.stores[r].modified, id)
+	return nil
+}
+
+func checkRequired(def resourceDef, obj map[string]interface{}) error {
+	for _, field := range def.required {
+		if !isSet(obj[field]) {
+			return errorf(http.StatusBadRequest, codeRequired, "%s is required", field)
+		}
+	}
+	return nil
+}
+
+func setDefault(obj map[string]interface{}, field string, v interface{}) {
+	if !isSet(obj[field]) {
+		obj[field] = v
+	}
+}
+
+func prepareCustomer(s *Server, obj map[string]interface{}) error {
+	setDefault(obj, "Active", true)
+	setDefault(obj, "Type", "COMPANY")
+	setDefault(obj, "Currency", "SEK")
+	return nil
+}
+
+func prepareArticle(s *Server, obj map[string]interface{}) error {
+	setDefault(obj, "Active", true)
+	setDefault(obj, "Type", "STOCK")
+	return nil
+}
+
+func prepareLabel(s *Server, obj map[string]interface{}) error {
+	for _, other := range s.stores[Labels].objects {
+		if fmt.Sprint(other["Id"]) != fmt.Sprint(obj["Id"]) && strings.EqualFold(fmt.Sprint(other["Description"]), fmt.Sprint(obj["Description"])) {
+			return invalid("label %s already exists", obj["Description"])
+		}
+	}
+	return nil
+}
+
+// prepareDocument fills in the customer and totals of an order or invoice
+func (s *Server) prepareDocument(obj map[string]interface{}, rows, quantity string) error {
+	customerNumber := fmt.Sprint(obj["CustomerNumber"])
+	customer, ok := s.stores[Customers].objects[customerNumber]
+	if !ok {
+		return invalid("customer %s not found", customerNumber)
+	}
+	setDefault(obj, "CustomerName", customer["Name"])
+	setDefault(obj, "Currency", "SEK")
+	setDefault(obj, "Cancelled", false)
+	setDefault(obj, "Sent", false)
+
+	var net, vat float64
+	rowList, _ := obj[rows].([]interface{})
+	for _, r := range rowList {
+		row, ok := r.(map[string]interface{})
+		if !ok {
+			return invalid("invalid %s", rows)
+		}
+		total := toFloat(row[quantity]) * toFloat(row["Price"])
+		if discount := toFloat(row["Discount"]); discount != 0 {
+			if row["DiscountType"] == "AMOUNT" {
+				total -= discount
+			} else {
+				total *= 1 - discount/100
+			}
+		}
+		total = round(total)
+		row["Total"] = total
+
+		rate := 25.0
+		if isSet(row["VAT"]) {
+			rate = toFloat(row["VAT"])
+		}
+		net += total
+		vat += total * rate / 100
+	}
+	if obj[rows] == nil {
+		obj[rows] = []interface{}{}
+	}
+	obj["Net"] = round(net)
+	obj["TotalVAT"] = round(vat)
+	obj["Total"] = round(net + vat)
+	return nil
+}
+
+func prepareOrder(s *Server, obj map[string]interface{}) error {
+	setDefault(obj, "OrderDate", s.now().Format(dateFormat))
+	return s.prepareDocument(obj, "OrderRows", "OrderedQuantity")
+}
+
+func prepareInvoice(s *Server, obj map[string]interface{}) error {
+	now := s.now()
+	setDefault(obj, "InvoiceDate", now.Format(dateFormat))
+	setDefault(obj, "DueDate", now.AddDate(0, 0, 30).Format(dateFormat))
+	setDefault(obj, "Booked", false)
+	setDefault(obj, "Credit", "false")
+	if err := s.prepareDocument(obj, "InvoiceRows", "DeliveredQuantity"); err != nil {
+		return err
+	}
+	// payments aren't faked, so nothing is ever paid
+	obj["Balance"] = obj["Total"]
+	return nil
+}
+
+// respond answers with the object unchanged
+func respond(r Resource) func(*Server, map[string]interface{}) (int, interface{}, error) {
+	return func(s *Server, obj map[string]interface{}) (int, interface{}, error) {
+		return http.StatusOK, map[string]interface{}{resources[r].wrapper: copyObject(obj)}, nil
+	}
+}
+
+func markSent(r Resource) func(*Server, map[string]interface{}) (int, interface{}, error) {
+	return func(s *Server, obj map[string]interface{}) (int, interface{}, error) {
+		obj["Sent"] = true
+		return respond(r)(s, obj)
+	}
+}
+
+// printDocument answers with a placeholder pdf. Printing marks the document as sent, previewing doesn't
+func printDocument(r Resource, markAsSent bool) func(*Server, map[string]interface{}) (int, interface{}, error) {
+	return func(s *Server, obj map[string]interface{}) (int, interface{}, error) {
+		if markAsSent {
+			obj["Sent"] = true
+		}
+		return http.StatusOK, placeholderPDF(fmt.Sprintf("%s %v", resources[r].wrapper, obj["DocumentNumber"])), nil
+	}
+}
+
+func createInvoiceFromOrder(s *Server, order map[string]interface{}) (int, interface{}, error) {
+	id := fmt.Sprint(order["DocumentNumber"])
+	if isTrue(order["Cancelled"]) {
+		return 0, nil, invalid("order %s is cancelled", id)
+	}
+	if isSet(order["InvoiceReference"]) {
+		return 0, nil, invalid("order %s has already been invoiced", id)
+	}
+
+	fields := copyObject(order)
+	for _, k := range []string{"@url", "DocumentNumber", "OrderDate", "OrderRows", "InvoiceReference", "Cancelled", "Sent", "Net", "TotalVAT", "Total"} {
+		delete(fields, k)
+	}
+	rows, _ := order["OrderRows"].([]interface{})
+	rows, _ = copyObject(map[string]interface{}{"rows": rows})["rows"].([]interface{})
+	for _, r := range rows {
+		row, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if !isSet(row["DeliveredQuantity"]) {
+			row["DeliveredQuantity"] = row["OrderedQuantity"]
+		}
+		delete(row, "OrderedQuantity")
+	}
+	fields["InvoiceRows"] = rows
+	fields["OrderReference"] = id
+
+	invoice, err := s.create(Invoices, fields)
+	if err != nil {
+		return 0, nil, err
+	}
+	order["InvoiceReference"] = invoice["DocumentNumber"]
+	return http.StatusOK, map[string]interface{}{"Invoice": copyObject(invoice)}, nil
+}
+
+func cancelOrder(s *Server, order map[string]interface{}) (int, interface{}, error) {
+	if isSet(order["InvoiceReference"]) {
+		return 0, nil, invalid("order %v has been invoiced", order["DocumentNumber"])
+	}
+	order["Cancelled"] = true
+	return respond(Orders)(s, order)
+}
+
+func bookkeepInvoice(s *Server, invoice map[string]interface{}) (int, interface{}, error) {
+	if isTrue(invoice["Cancelled"]) {
+		return 0, nil, invalid("invoice %v is cancelled", invoice["DocumentNumber"])
+	}
+	if isTrue(invoice["Booked"]) {
+		return 0, nil, errorf(http.StatusBadRequest, codeAlreadyBooked, "invoice %v is already booked", invoice["DocumentNumber"])
+	}
+	invoice["Booked"] = true
+	return respond(Invoices)(s, invoice)
+}
+
+func cancelInvoice(s *Server, invoice map[string]interface{}) (int, interface{}, error) {
+	if isTrue(invoice["Booked"]) {
+		return 0, nil, errorf(http.StatusBadRequest, codeAlreadyBooked, "invoice %v is booked and can't be cancelled", invoice["DocumentNumber"])
+	}
+	invoice["Cancelled"] = true
+	return respond(Invoices)(s, invoice)
+}
+
+// creditInvoice creates a credit invoice with negated quantities, answering with the original invoice
+func creditInvoice(s *Server, invoice map[string]interface{}) (int, interface{}, error) {
+	id := fmt.Sprint(invoice["DocumentNumber"])
+	if !isTrue(invoice["Booked"]) {
+		return 0, nil, invalid("invoice %s must be booked before it can be credited", id)
+	}
+	if isSet(invoice["CreditInvoiceReference"]) {
+		return 0, nil, invalid("invoice %s has already been credited", id)
+	}
+
+	fields := copyObject(invoice)
+	for _, k := range []string{"@url", "DocumentNumber", "InvoiceDate", "DueDate", "Booked", "Sent", "Net", "TotalVAT", "Total", "Balance"} {
+		delete(fields, k)
+	}
+	rows, _ := fields["InvoiceRows"].([]interface{})
+	for _, r := range rows {
+		if row, ok := r.(map[string]interface{}); ok {
+			row["DeliveredQuantity"] = negate(row["DeliveredQuantity"])
+		}
+	}
+	fields["Credit"] = "true"
+	fields["CreditInvoiceReference"] = id
+
+	credit, err := s.create(Invoices, fields)
+	if err != nil {
+		return 0, nil, err
+	}
+	invoice["CreditInvoiceReference"] = credit["DocumentNumber"]
+	return respond(Invoices)(s, invoice)
+}
+
+// placeholderPDF is a minimal single page pdf
+func placeholderPDF(title string) pdf {
+	return pdf(fmt.Sprintf("%%PDF-1.4\n1 0 obj << /Type /Catalog /Pages 2 0 R >> endobj\n"+
+		"2 0 obj << /Type /Pages /Kids [3 0 R] /Count 1 >> endobj\n"+
+		"3 0 obj << /Type /Page /Parent 2 0 R /MediaBox [0 0 595 842] >> endobj\n"+
+		"trailer << /Root 1 0 R /Title (%s) >>\n%%%%EOF\n", title))
+}
+
+func isSet(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case string:
+		return v != "" && v != "0"
+	case float64:
+		return v != 0
+	}
+	return true
+}
+
+func isTrue(v interface{}) bool {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case string:
+		b, _ := strconv.ParseBool(v)
+		return b
+	}
+	return false
+}
+
+// toFloat reads numbers which may have been sent as strings
+func toFloat(v interface{}) float64 {
+	switch v := v.(type) {
+	case float64:
+		return v
+	case string:
+		f, _ := strconv.ParseFloat(strings.Replace(v, ",", ".", 1), 64)
+		return f
+	}
+	return 0
+}
+
+// negate keeps the type the value was sent as
+func negate(v interface{}) interface{} {
+	switch v := v.(type) {
+	case float64:
+		return -v
+	case string:
+		return strconv.FormatFloat(-toFloat(v), 'f', -1, 64)
+	}
+	return v
+}
+
+func round(f float64) float64 {
+	return math.Round(f*100) / 100
+}
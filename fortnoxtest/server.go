@@ -0,0 +1,412 @@
+// Package fortnoxtest provides an in-process fake of the fortnox api, for testing code using go-fortnox without
+// network access or a fortnox account.
+//
+// The fake keeps customers, articles, orders, invoices and labels in memory, assigns numbers the way fortnox does,
+// validates required fields and answers with fortnox shaped errors. Lists can be searched, filtered and paged, and
+// the lastmodified parameter is honoured, so code built on fortnox.Syncer can be tested too. Faults such as rate
+// limiting, server errors and latency can be injected.
+//
+// Only the company settings are served besides those resources. Offers, suppliers, supplier invoices, vouchers,
+// accounts, financial years, invoice and supplier invoice payments, projects, cost centers, price lists and prices,
+// contracts and contract templates, currencies, units and the other registers, the archive, the inbox, attachments
+// and file connections aren't implemented and answer 404. Test code using those against canned responses instead.
+//
+//	srv := fortnoxtest.NewServer()
+//	defer srv.Close()
+//	client := fortnox.NewClient(fortnox.WithAuthOpts("token", "secret"), fortnox.WithURLOpts(srv.BaseURL()))
+package fortnoxtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Resource is a collection held by the fake
+type Resource string
+
+// Resources the fake implements
+const (
+	Customers Resource = "customers"
+	Articles  Resource = "articles"
+	Orders    Resource = "orders"
+	Invoices  Resource = "invoices"
+	Labels    Resource = "labels"
+)
+
+const apiPrefix = "/3/"
+
+// error codes the client maps to its sentinel errors
+const (
+	codeInvalidToken  = 2000311
+	codeNumeric       = 2000108
+	codeRequired      = 2000357
+	codeAlreadyBooked = 2000588
+)
+
+// Fault makes matching requests fail, or slows them down
+type Fault struct {
+	// Method and Path select the affected requests, empty matches all. Path is a prefix of the resource, e.g. "invoices"
+	Method string
+	Path   string
+	// Status to fail with, 0 to only add latency
+	Status int
+	// Latency before responding
+	Latency time.Duration
+	// RetryAfter is sent with the failure if set
+	RetryAfter time.Duration
+	// Times is how many requests are affected, 0 for all of them
+	Times int
+}
+
+func (f *Fault) matches(method, path string) bool {
+	return (f.Method == "" || strings.EqualFold(f.Method, method)) && strings.HasPrefix(path, f.Path)
+}
+
+// Request is a request received by the fake
+type Request struct {
+	Method string
+	// Path is the resource path, without the api prefix
+	Path string
+}
+
+// Server is a fake fortnox api. Its zero value isn't usable, create one with NewServer
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	stores   map[Resource]*store
+	company  map[string]interface{}
+	faults   []*Fault
+	requests []Request
+	now      func() time.Time
+}
+
+// NewServer starts a fake with no data besides company settings. Close it when done
+func NewServer() *Server {
+	s := &Server{
+		stores: map[Resource]*store{},
+		company: map[string]interface{}{
+			"Name":               "Fortnoxtest AB",
+			"OrganizationNumber": "556000-0000",
+			"Address":            "Testgatan 1",
+			"ZipCode":            "411 01",
+			"City":               "Göteborg",
+			"Country":            "Sverige",
+			"CountryCode":        "SE",
+			"DatabaseNumber":     1,
+			"TaxEnabled":         true,
+		},
+		now: time.Now,
+	}
+	for r := range resources {
+		s.stores[r] = newStore()
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// BaseURL is the url to give the client, with fortnox.WithURLOpts
+func (s *Server) BaseURL() string {
+	return s.URL + apiPrefix
+}
+
+// InjectFault adds a fault. Faults are checked in the order they were added and the first match applies
+func (s *Server) InjectFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes all faults
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests lists the requests received so far, including failed ones
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// Add stores an object as if it had been posted to the api, returning it with the fields fortnox fills in
+func (s *Server) Add(r Resource, fields map[string]interface{}) (map[string]interface{}, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := resources[r]; !ok {
+		return nil, fmt.Errorf("fortnoxtest: unknown resource %q", r)
+	}
+	obj, err := s.create(r, copyObject(fields))
+	if err != nil {
+		return nil, err
+	}
+	return copyObject(obj), nil
+}
+
+// Get returns a copy of a stored object
+func (s *Server) Get(r Resource, id string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, ok := s.stores[r]
+	if !ok {
+		return nil, false
+	}
+	obj, ok := st.objects[id]
+	if !ok {
+		return nil, false
+	}
+	return copyObject(obj), true
+}
+
+// SetClock replaces the clock used for dates and modification times, which defaults to time.Now
+func (s *Server) SetClock(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.now = now
+}
+
+// SetCompanySettings overwrites the given company settings fields
+func (s *Server) SetCompanySettings(fields map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for k, v := range copyObject(fields) {
+		s.company[k] = v
+	}
+}
+
+// apiError is answered as fortnox's ErrorInformation
+type apiError struct {
+	Status  int
+	Code    int
+	Message string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%d - %s", e.Code, e.Message)
+}
+
+func errorf(status, code int, format string, args ...interface{}) *apiError {
+	return &apiError{Status: status, Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+func notFound(format string, args ...interface{}) *apiError {
+	return errorf(http.StatusNotFound, 0, format, args...)
+}
+
+func invalid(format string, args ...interface{}) *apiError {
+	return errorf(http.StatusBadRequest, 0, format, args...)
+}
+
+// pdf is a binary response
+type pdf []byte
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, apiPrefix)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: path})
+	fault := s.fault(r.Method, path)
+	s.mu.Unlock()
+
+	if fault != nil {
+		if fault.Latency > 0 {
+			select {
+			case <-time.After(fault.Latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if fault.Status != 0 {
+			if fault.RetryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int((fault.RetryAfter+time.Second-1)/time.Second)))
+			}
+			writeError(w, errorf(fault.Status, 0, "%s", http.StatusText(fault.Status)))
+			return
+		}
+	}
+
+	if !strings.HasPrefix(r.URL.Path, apiPrefix) {
+		writeError(w, notFound("no such path %s", r.URL.Path))
+		return
+	}
+	if !authorized(r) {
+		writeError(w, errorf(http.StatusUnauthorized, codeInvalidToken, "Invalid access token"))
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, invalid("could not read body"))
+		return
+	}
+
+	s.mu.Lock()
+	status, result, err := s.route(r.Method, strings.Split(strings.Trim(path, "/"), "/"), r.URL.Query(), body)
+	s.mu.Unlock()
+
+	if err != nil {
+		if aErr, ok := err.(*apiError); ok {
+			writeError(w, aErr)
+			return
+		}
+		writeError(w, errorf(http.StatusInternalServerError, 0, "%s", err))
+		return
+	}
+
+	switch v := result.(type) {
+	case nil:
+		w.WriteHeader(status)
+	case pdf:
+		w.Header().Set("Content-Type", "application/pdf")
+		w.WriteHeader(status)
+		w.Write(v)
+	default:
+		writeJSON(w, status, v)
+	}
+}
+
+// fault finds the first matching fault, using up one of its times
+func (s *Server) fault(method, path string) *Fault {
+	for i, f := range s.faults {
+		if !f.matches(method, path) {
+			continue
+		}
+		applied := *f
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i:i], s.faults[i+1:]...)
+			}
+		}
+		return &applied
+	}
+	return nil
+}
+
+// authorized accepts any credentials, since the fake has no accounts
+func authorized(r *http.Request) bool {
+	if r.Header.Get("Access-Token") != "" {
+		return true
+	}
+	token := strings.TrimPrefix(strings.TrimSpace(r.Header.Get("Authorization")), "Bearer")
+	return strings.TrimSpace(token) != ""
+}
+
+func (s *Server) route(method string, segs []string, q url.Values, body []byte) (int, interface{}, error) {
+	if len(segs) == 2 && segs[0] == "settings" && segs[1] == "company" {
+		if method != "GET" {
+			return 0, nil, errorf(http.StatusMethodNotAllowed, 0, "method %s not allowed", method)
+		}
+		return http.StatusOK, map[string]interface{}{"CompanySettings": copyObject(s.company)}, nil
+	}
+
+	r := Resource(segs[0])
+	def, ok := resources[r]
+	if !ok {
+		return 0, nil, notFound("no such resource %s", segs[0])
+	}
+
+	switch {
+	case len(segs) == 1 && method == "GET":
+		return s.list(r, q)
+	case len(segs) == 1 && method == "POST":
+		fields, err := decodeWrapped(body, def.wrapper)
+		if err != nil {
+			return 0, nil, err
+		}
+		obj, err := s.create(r, fields)
+		if err != nil {
+			return 0, nil, err
+		}
+		return http.StatusCreated, map[string]interface{}{def.wrapper: copyObject(obj)}, nil
+	case len(segs) == 2 && method == "GET":
+		obj, err := s.lookup(r, segs[1])
+		if err != nil {
+			return 0, nil, err
+		}
+		return http.StatusOK, map[string]interface{}{def.wrapper: copyObject(obj)}, nil
+	case len(segs) == 2 && method == "PUT":
+		fields, err := decodeWrapped(body, def.wrapper)
+		if err != nil {
+			return 0, nil, err
+		}
+		obj, err := s.update(r, segs[1], fields)
+		if err != nil {
+			return 0, nil, err
+		}
+		return http.StatusOK, map[string]interface{}{def.wrapper: copyObject(obj)}, nil
+	case len(segs) == 2 && method == "DELETE" && def.deletable:
+		if err := s.delete(r, segs[1]); err != nil {
+			return 0, nil, err
+		}
+		return http.StatusNoContent, nil, nil
+	case len(segs) == 3:
+		action, ok := def.actions[segs[2]]
+		if !ok || action.method != method {
+			break
+		}
+		obj, err := s.lookup(r, segs[1])
+		if err != nil {
+			return 0, nil, err
+		}
+		before := copyObject(obj)
+		status, result, err := action.do(s, obj)
+		if err == nil && !reflect.DeepEqual(before, copyObject(obj)) {
+			s.stores[r].modified[segs[1]] = s.now()
+		}
+		return status, result, err
+	}
+	return 0, nil, errorf(http.StatusMethodNotAllowed, 0, "method %s not allowed on %s", method, strings.Join(segs, "/"))
+}
+
+func decodeWrapped(body []byte, wrapper string) (map[string]interface{}, error) {
+	var req map[string]map[string]interface{}
+	if err := json.Unmarshal(body, &req); err != nil {
+		return nil, invalid("invalid json: %s", err)
+	}
+	fields, ok := req[wrapper]
+	if !ok || fields == nil {
+		return nil, invalid("expected a %s object", wrapper)
+	}
+	return fields, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err *apiError) {
+	writeJSON(w, err.Status, map[string]interface{}{
+		"ErrorInformation": map[string]interface{}{
+			"Error":   1,
+			"Message": err.Message,
+			"Code":    err.Code,
+		},
+	})
+}
+
+// copyObject deep copies an object, normalising it to what json decoding gives
+func copyObject(obj map[string]interface{}) map[string]interface{} {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		panic(err)
+	}
+	cp := map[string]interface{}{}
+	if err := json.Unmarshal(data, &cp); err != nil {
+		panic(err)
+	}
+	return cp
+}
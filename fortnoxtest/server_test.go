@@ -0,0 +1,296 @@
+package fortnoxtest
+
+import (
+	"context"
+	"fmt"
+	"github.com/byrnedo/go-fortnox"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func newTestClient(s *Server, opts ...fortnox.OptionsFunc) *fortnox.Client {
+	opts = append([]fortnox.OptionsFunc{
+		fortnox.WithAuthOpts("token", "secret"),
+		fortnox.WithURLOpts(s.BaseURL()),
+		fortnox.WithRateLimitOpts(0, 0, 0),
+		fortnox.WithRetryOpts(fortnox.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}),
+	}, opts...)
+	return fortnox.NewClient(opts...)
+}
+
+func str(s string) *string { return &s }
+
+func flt(f float64) *float64 { return &f }
+
+func TestServer_AssignsNumbersAndValidates(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(s)
+	ctx := context.Background()
+
+	for i, name := range []string{"first", "second"} {
+		cust, err := c.CreateCustomer(ctx, &fortnox.CreateCustomer{Name: str(name)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cust.CustomerNumber != fmt.Sprint(i+1) {
+			t.Fatal("unexpected customer number", cust.CustomerNumber)
+		}
+	}
+
+	if _, err := c.CreateCustomer(ctx, &fortnox.CreateCustomer{City: str("Göteborg")}); !fortnox.IsValidation(err) {
+		t.Fatal("expected validation error, got", err)
+	}
+	if _, err := c.CreateOrder(ctx, &fortnox.CreateOrder{CustomerNumber: str("404")}); !fortnox.IsValidation(err) {
+		t.Fatal("expected validation error for unknown customer, got", err)
+	}
+
+	order, err := c.CreateOrder(ctx, &fortnox.CreateOrder{
+		CustomerNumber: str("2"),
+		OrderRows: []*fortnox.CreateOrderRow{
+			{Description: str("widget"), OrderedQuantity: str("2"), Price: flt(50)},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.DocumentNumber != 1 || order.CustomerName != "second" {
+		t.Fatalf("unexpected order %d for %q", order.DocumentNumber, order.CustomerName)
+	}
+	if order.Net != 100 || order.Total != 125 || order.OrderRows[0].Total != 100 {
+		t.Fatalf("unexpected totals, net %v total %v", order.Net, order.Total)
+	}
+
+	if _, err := c.GetOrder(ctx, 2); !fortnox.IsNotFound(err) {
+		t.Fatal("expected not found, got", err)
+	}
+	if err := c.DeleteCustomer(ctx, "2"); !fortnox.IsValidation(err) {
+		t.Fatal("customer with orders shouldn't be deletable, got", err)
+	}
+}
+
+func TestServer_InvoiceLifecycle(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(s)
+	ctx := context.Background()
+
+	if _, err := s.Add(Customers, map[string]interface{}{"CustomerNumber": "K1", "Name": "Kund"}); err != nil {
+		t.Fatal(err)
+	}
+	order, err := c.CreateOrder(ctx, &fortnox.CreateOrder{
+		CustomerNumber: str("K1"),
+		OrderRows:      []*fortnox.CreateOrderRow{{Description: str("text row")}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	invoice, err := c.CreateInvoiceFromOrder(ctx, int(order.DocumentNumber))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if invoice.OrderReference != order.DocumentNumber || len(invoice.InvoiceRows) != 1 {
+		t.Fatalf("unexpected invoice %+v", invoice)
+	}
+	if _, err := c.CreateInvoiceFromOrder(ctx, int(order.DocumentNumber)); !fortnox.IsValidation(err) {
+		t.Fatal("expected order to be invoiced only once, got", err)
+	}
+
+	if _, err := c.BookkeepInvoice(ctx, int(invoice.DocumentNumber)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.UpdateInvoice(ctx, int(invoice.DocumentNumber), &fortnox.UpdateInvoice{DeliveryCity: str("Malmö")}); !fortnox.IsAlreadyBooked(err) {
+		t.Fatal("expected already booked, got", err)
+	}
+
+	credited, err := c.CreditInvoice(ctx, int(invoice.DocumentNumber))
+	if err != nil {
+		t.Fatal(err)
+	}
+	credit, ok := s.Get(Invoices, fmt.Sprint(credited.CreditInvoiceReference))
+	if !ok || credit["Credit"] != "true" {
+		t.Fatalf("expected a credit invoice, got %v", credit)
+	}
+
+	doc, err := c.PreviewInvoicePDF(ctx, int(invoice.DocumentNumber))
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc.Close()
+	if doc.ContentType != "application/pdf" {
+		t.Fatal("unexpected content type", doc.ContentType)
+	}
+}
+
+func TestServer_ListsPages(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(s)
+
+	for i := 0; i < 120; i++ {
+		city := "Göteborg"
+		if i%2 == 0 {
+			city = "Malmö"
+		}
+		if _, err := s.Add(Customers, map[string]interface{}{"Name": fmt.Sprint("customer ", i), "City": city}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	it := c.Customers(context.Background(), &fortnox.CustomerQueryParams{City: "malmö", Limit: 25})
+	defer it.Close()
+	n := 0
+	for it.Next() {
+		n++
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n != 60 {
+		t.Fatal("expected 60 customers, got", n)
+	}
+}
+
+func TestServer_Faults(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(s)
+	ctx := context.Background()
+
+	s.InjectFault(Fault{Path: "labels", Status: http.StatusTooManyRequests, Times: 2})
+	if _, err := c.ListLabels(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(s.Requests()); n != 3 {
+		t.Fatal("expected 2 retries, got requests:", n)
+	}
+
+	s.InjectFault(Fault{Method: "POST", Status: http.StatusInternalServerError})
+	if _, err := c.CreateLabel(ctx, "test"); !fortnox.IsServerError(err) {
+		t.Fatal("expected server error, got", err)
+	}
+	s.ClearFaults()
+
+	s.InjectFault(Fault{Latency: time.Second})
+	timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if _, err := c.GetCompanySettings(timeoutCtx); err == nil {
+		t.Fatal("expected timeout")
+	}
+	s.ClearFaults()
+
+	settings, err := c.GetCompanySettings(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if settings.Name == "" {
+		t.Fatal("expected company name")
+	}
+}
+
+func TestServer_Unauthorized(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(s, fortnox.WithAuthOpts("", ""))
+
+	if _, err := c.ListLabels(context.Background()); !fortnox.IsUnauthorized(err) {
+		t.Fatal("expected unauthorized, got", err)
+	}
+}
+
+func TestServer_DoesNotRetryActions(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(s)
+	ctx := context.Background()
+
+	if _, err := s.Add(Customers, map[string]interface{}{"CustomerNumber": "K1", "Name": "Kund"}); err != nil {
+		t.Fatal(err)
+	}
+	order, err := s.Add(Orders, map[string]interface{}{"CustomerNumber": "K1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	invoice, err := s.Add(Invoices, map[string]interface{}{"CustomerNumber": "K1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	orderNumber, _ := strconv.Atoi(fmt.Sprint(order["DocumentNumber"]))
+	invoiceNumber, _ := strconv.Atoi(fmt.Sprint(invoice["DocumentNumber"]))
+
+	count := func(path string) int {
+		n := 0
+		for _, r := range s.Requests() {
+			if r.Path == path {
+				n++
+			}
+		}
+		return n
+	}
+
+	s.InjectFault(Fault{Status: http.StatusInternalServerError})
+	if _, err := c.CreateInvoiceFromOrder(ctx, orderNumber); !fortnox.IsServerError(err) {
+		t.Fatal("expected server error, got", err)
+	}
+	if n := count(fmt.Sprintf("orders/%d/createinvoice", orderNumber)); n != 1 {
+		t.Fatal("expected createinvoice to be sent once, got", n)
+	}
+	if _, err := c.BookkeepInvoice(ctx, invoiceNumber); !fortnox.IsServerError(err) {
+		t.Fatal("expected server error, got", err)
+	}
+	if n := count(fmt.Sprintf("invoices/%d/bookkeep", invoiceNumber)); n != 1 {
+		t.Fatal("expected bookkeep to be sent once, got", n)
+	}
+	if _, err := c.GetOrder(ctx, orderNumber); !fortnox.IsServerError(err) {
+		t.Fatal("expected server error, got", err)
+	}
+	if n := count(fmt.Sprintf("orders/%d", orderNumber)); n != 3 {
+		t.Fatal("expected reads to be retried, got requests:", n)
+	}
+	s.ClearFaults()
+
+	// rate limited actions never reached fortnox, so they are retried
+	s.InjectFault(Fault{Status: http.StatusTooManyRequests, Times: 1})
+	if _, err := c.BookkeepInvoice(ctx, invoiceNumber); err != nil {
+		t.Fatal(err)
+	}
+	if n := count(fmt.Sprintf("invoices/%d/bookkeep", invoiceNumber)); n != 3 {
+		t.Fatal("expected the rate limited bookkeep to be retried, got requests:", n)
+	}
+}
+
+func TestServer_LastModified(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+	c := newTestClient(s)
+	ctx := context.Background()
+
+	now := time.Date(2020, 3, 10, 12, 0, 0, 0, time.UTC)
+	s.SetClock(func() time.Time { return now })
+	for _, name := range []string{"Old", "Changed", "New"} {
+		if _, err := s.Add(Customers, map[string]interface{}{"Name": name}); err != nil {
+			t.Fatal(err)
+		}
+		now = now.Add(time.Hour)
+	}
+	if _, err := c.UpdateCustomer(ctx, "2", &fortnox.UpdateCustomer{City: str("Malmö")}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 14:00 utc is 15:00 in stockholm
+	since := time.Date(2020, 3, 10, 14, 0, 0, 0, time.UTC)
+	resp, err := c.ListCustomers(ctx, &fortnox.CustomerQueryParams{LastModified: since})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, cust := range resp.Customers {
+		names = append(names, cust.Name)
+	}
+	if fmt.Sprint(names) != "[Changed New]" {
+		t.Fatal("unexpected customers", names)
+	}
+}
@@ -0,0 +1,55 @@
+package fortnox
+
+import (
+	"context"
+	"io"
+	"net/url"
+)
+
+// Inbox folders. Files uploaded to them show up in fortnox for the matching kind of document
+const (
+	InboxSupplierInvoices = "inbox_s"
+	InboxVouchers         = "inbox_v"
+	InboxCustomerInvoices = "inbox_kf"
+	InboxOrders           = "inbox_o"
+	InboxOffers           = "inbox_of"
+	InboxAssetRegister    = "inbox_a"
+	InboxBankFiles        = "inbox_b"
+	InboxDailyTakings     = "inbox_d"
+)
+
+// GetInboxFolder gets a folder of the inbox by path, usually one of the Inbox constants. An empty path gets the root folder
+func (c *Client) GetInboxFolder(ctx context.Context, path string) (*ArchiveFolder, error) {
+
+	resp := &ArchiveFolderResp{}
+
+	err := c.request(ctx, "GET", "inbox", nil, pathValues(path), resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Folder, nil
+}
+
+// UploadInboxFile uploads a file to a folder of the inbox, usually one of the Inbox constants
+func (c *Client) UploadInboxFile(ctx context.Context, folder string, filename string, r io.Reader) (*ArchiveFile, error) {
+
+	resp := &ArchiveFileResp{}
+
+	err := c.upload(ctx, "inbox", pathValues(folder), filename, r, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.File, nil
+}
+
+// DownloadInboxFile streams the content of a file in the inbox. The caller must close it
+func (c *Client) DownloadInboxFile(ctx context.Context, id string) (*Document, error) {
+	return c.getDocumentStream(ctx, "inbox/"+url.PathEscape(id), nil, id, false)
+}
+
+// DeleteInboxFile deletes a file in the inbox
+func (c *Client) DeleteInboxFile(ctx context.Context, id string) error {
+	return c.deleteResource(ctx, "inbox/"+url.PathEscape(id))
+}
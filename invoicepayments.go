@@ -0,0 +1,199 @@
+package fortnox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// PaymentWriteOff books part of a payment on another account, e.g. an exchange rate difference or a rounding
+type PaymentWriteOff struct {
+	AccountNumber          int      `json:"AccountNumber"`
+	Amount                 Floatish `json:"Amount"`
+	CostCenter             string   `json:"CostCenter"`
+	Currency               string   `json:"Currency"`
+	Description            string   `json:"Description"`
+	Project                string   `json:"Project"`
+	TransactionInformation string   `json:"TransactionInformation"`
+}
+
+// CreatePaymentWriteOff payload for payment write offs
+type CreatePaymentWriteOff struct {
+	AccountNumber          *int     `json:"AccountNumber,omitempty"`
+	Amount                 *float64 `json:"Amount,omitempty"`
+	CostCenter             *string  `json:"CostCenter,omitempty"`
+	Currency               *string  `json:"Currency,omitempty"`
+	Description            *string  `json:"Description,omitempty"`
+	Project                *string  `json:"Project,omitempty"`
+	TransactionInformation *string  `json:"TransactionInformation,omitempty"`
+}
+
+// InvoicePayment data type
+type InvoicePayment struct {
+	URL                       string            `json:"@url"`
+	Amount                    Floatish          `json:"Amount"`
+	AmountCurrency            Floatish          `json:"AmountCurrency"`
+	Booked                    bool              `json:"Booked"`
+	Currency                  string            `json:"Currency"`
+	CurrencyRate              Floatish          `json:"CurrencyRate"`
+	CurrencyUnit              Floatish          `json:"CurrencyUnit"`
+	ExternalInvoiceReference1 string            `json:"ExternalInvoiceReference1"`
+	ExternalInvoiceReference2 string            `json:"ExternalInvoiceReference2"`
+	InvoiceCustomerName       string            `json:"InvoiceCustomerName"`
+	InvoiceCustomerNumber     string            `json:"InvoiceCustomerNumber"`
+	InvoiceDueDate            Date              `json:"InvoiceDueDate"`
+	InvoiceNumber             Intish            `json:"InvoiceNumber"`
+	InvoiceOCR                string            `json:"InvoiceOCR"`
+	InvoiceTotal              Floatish          `json:"InvoiceTotal"`
+	ModeOfPayment             string            `json:"ModeOfPayment"`
+	ModeOfPaymentAccount      Intish            `json:"ModeOfPaymentAccount"`
+	Number                    Intish            `json:"Number"`
+	PaymentDate               Date              `json:"PaymentDate"`
+	Source                    string            `json:"Source"`
+	VoucherNumber             Intish            `json:"VoucherNumber"`
+	VoucherSeries             string            `json:"VoucherSeries"`
+	VoucherYear               Intish            `json:"VoucherYear"`
+	WriteOffs                 []PaymentWriteOff `json:"WriteOffs"`
+}
+
+// CreateInvoicePayment payload for registering invoice payments.
+// For payments in foreign currency set AmountCurrency and CurrencyRate, the exchange difference is booked with WriteOffs
+type CreateInvoicePayment struct {
+	Amount                    *float64                 `json:"Amount,omitempty"`
+	AmountCurrency            *float64                 `json:"AmountCurrency,omitempty"`
+	CurrencyRate              *float64                 `json:"CurrencyRate,omitempty"`
+	CurrencyUnit              *float64                 `json:"CurrencyUnit,omitempty"`
+	ExternalInvoiceReference1 *string                  `json:"ExternalInvoiceReference1,omitempty"`
+	ExternalInvoiceReference2 *string                  `json:"ExternalInvoiceReference2,omitempty"`
+	InvoiceNumber             *int                     `json:"InvoiceNumber,omitempty"`
+	ModeOfPayment             *string                  `json:"ModeOfPayment,omitempty"`
+	ModeOfPaymentAccount      *int                     `json:"ModeOfPaymentAccount,omitempty"`
+	PaymentDate               *string                  `json:"PaymentDate,omitempty"`
+	WriteOffs                 []*CreatePaymentWriteOff `json:"WriteOffs,omitempty"`
+}
+
+// UpdateInvoicePayment payload for updating invoice payments. Only unbooked payments can be updated
+type UpdateInvoicePayment CreateInvoicePayment
+
+// InvoicePaymentQueryParams when searching invoice payments
+type InvoicePaymentQueryParams struct {
+	InvoiceNumber int
+	LastModified  time.Time
+	Page          int
+	Limit         int
+	Offset        int
+	Extra         map[string][]string
+}
+
+func (p InvoicePaymentQueryParams) toValues() url.Values {
+
+	ret := make(url.Values)
+
+	if p.InvoiceNumber > 0 {
+		ret["invoicenumber"] = []string{fmt.Sprintf("%d", p.InvoiceNumber)}
+	}
+	if !p.LastModified.IsZero() {
+		ret["lastmodified"] = []string{p.LastModified.Format(TimeFormat)}
+	}
+	if p.Limit > 0 {
+		ret["limit"] = []string{fmt.Sprintf("%d", p.Limit)}
+	}
+	if p.Offset > 0 {
+		ret["offset"] = []string{fmt.Sprintf("%d", p.Offset)}
+	}
+	if p.Page > 0 {
+		ret["page"] = []string{fmt.Sprintf("%d", p.Page)}
+	}
+	for k, vs := range p.Extra {
+		ret[k] = vs
+	}
+	return ret
+}
+
+// ListInvoicePaymentsResp Response when listing invoice payments
+type ListInvoicePaymentsResp struct {
+	InvoicePayments []*InvoicePayment `json:"InvoicePayments"`
+	MetaInformation *MetaInformation  `json:"MetaInformation"`
+}
+
+// ListInvoicePayments lists invoice payments
+func (c *Client) ListInvoicePayments(ctx context.Context, p *InvoicePaymentQueryParams) (*ListInvoicePaymentsResp, error) {
+	resp := &ListInvoicePaymentsResp{}
+
+	var vals url.Values
+	if p != nil {
+		vals = p.toValues()
+	}
+
+	err := c.request(ctx, "GET", "invoicepayments", nil, vals, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// InvoicePaymentResp Response for single invoice payment
+type InvoicePaymentResp struct {
+	InvoicePayment InvoicePayment `json:"InvoicePayment"`
+}
+
+// GetInvoicePayment gets one invoice payment by number
+func (c *Client) GetInvoicePayment(ctx context.Context, number int) (*InvoicePayment, error) {
+
+	resp := &InvoicePaymentResp{}
+
+	err := c.request(ctx, "GET", "invoicepayments/"+strconv.Itoa(number), nil, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.InvoicePayment, nil
+}
+
+// CreateInvoicePayment registers a payment of an invoice
+func (c *Client) CreateInvoicePayment(ctx context.Context, payment *CreateInvoicePayment) (*InvoicePayment, error) {
+	resp := &InvoicePaymentResp{}
+	err := c.request(ctx, "POST", "invoicepayments/", &struct {
+		InvoicePayment *CreateInvoicePayment `json:"InvoicePayment"`
+	}{
+		InvoicePayment: payment,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.InvoicePayment, nil
+}
+
+// UpdateInvoicePayment updates an invoice payment
+func (c *Client) UpdateInvoicePayment(ctx context.Context, number int, payment *UpdateInvoicePayment) (*InvoicePayment, error) {
+	resp := &InvoicePaymentResp{}
+	err := c.request(ctx, "PUT", "invoicepayments/"+strconv.Itoa(number), &struct {
+		InvoicePayment *UpdateInvoicePayment `json:"InvoicePayment"`
+	}{
+		InvoicePayment: payment,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.InvoicePayment, nil
+}
+
+// DeleteInvoicePayment deletes an unbooked invoice payment
+func (c *Client) DeleteInvoicePayment(ctx context.Context, number int) error {
+	return c.deleteResource(ctx, "invoicepayments/"+strconv.Itoa(number))
+}
+
+// BookkeepInvoicePayment bookkeeps an invoice payment
+func (c *Client) BookkeepInvoicePayment(ctx context.Context, number int) (*InvoicePayment, error) {
+	resp := &InvoicePaymentResp{}
+	err := c.action(ctx, "PUT", fmt.Sprintf("invoicepayments/%d/bookkeep", number), resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.InvoicePayment, nil
+}
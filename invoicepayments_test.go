@@ -0,0 +1,59 @@
+package fortnox
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestClient_InvoicePayments(t *testing.T) {
+	api := newStubAPI(t, map[string]string{
+		"GET invoicepayments":            `{"InvoicePayments":[{"Number":"8","InvoiceNumber":"42","Amount":"100.00","PaymentDate":"2020-02-10","Booked":false}],"MetaInformation":{"@CurrentPage":1,"@TotalPages":1,"@TotalResources":1}}`,
+		"POST invoicepayments/":          `{"InvoicePayment":{"Number":"8","InvoiceNumber":"42","Amount":"90.00","WriteOffs":[{"AccountNumber":3960,"Amount":"10.00"}]}}`,
+		"DELETE invoicepayments/8":       ``,
+		"PUT invoicepayments/8/bookkeep": `{"InvoicePayment":{"Number":"8","Booked":true}}`,
+	})
+	defer api.Close()
+	c := api.client()
+	ctx := context.Background()
+
+	list, err := c.ListInvoicePayments(ctx, &InvoicePaymentQueryParams{InvoiceNumber: 42})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.InvoicePayments) != 1 || list.InvoicePayments[0].Number != 8 || list.InvoicePayments[0].Amount != 100 {
+		t.Fatalf("unexpected payments %+v", list.InvoicePayments)
+	}
+	if q := api.received("GET invoicepayments")[0].Query; q.Get("invoicenumber") != "42" {
+		t.Fatal("unexpected query", q)
+	}
+
+	invoice, amount, account, diff := 42, 90.0, 3960, 10.0
+	p, err := c.CreateInvoicePayment(ctx, &CreateInvoicePayment{
+		InvoiceNumber: &invoice,
+		Amount:        &amount,
+		WriteOffs:     []*CreatePaymentWriteOff{{AccountNumber: &account, Amount: &diff}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.WriteOffs) != 1 || p.WriteOffs[0].Amount != 10 {
+		t.Fatalf("unexpected write offs %+v", p.WriteOffs)
+	}
+	if body := api.received("POST invoicepayments/")[0].Body; body != `{"InvoicePayment":{"Amount":90,"InvoiceNumber":42,"WriteOffs":[{"AccountNumber":3960,"Amount":10}]}}`+"\n" {
+		t.Fatal("unexpected body", body)
+	}
+
+	// bookkeeping may have happened before the server failed, so it isn't retried
+	api.fail("PUT invoicepayments/8/bookkeep", http.StatusServiceUnavailable)
+	if _, err := c.BookkeepInvoicePayment(ctx, 8); !IsServerError(err) {
+		t.Fatal("expected server error, got", err)
+	}
+	if n := len(api.received("PUT invoicepayments/8/bookkeep")); n != 1 {
+		t.Fatal("expected 1 request, got", n)
+	}
+
+	if err := c.DeleteInvoicePayment(ctx, 8); err != nil {
+		t.Fatal(err)
+	}
+}
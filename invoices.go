@@ -321,3 +321,111 @@ func (c *Client) UpdateInvoice(ctx context.Context, id int, invoice *UpdateInvoi
 
 	return &resp.Invoice, nil
 }
+
+// InvoiceIterator walks through every page of invoices
+type InvoiceIterator struct {
+	it *pageIterator
+}
+
+// Invoices iterates over all invoices matching the params, fetching pages as needed
+func (c *Client) Invoices(ctx context.Context, p *InvoiceQueryParams, opts ...IteratorOption) *InvoiceIterator {
+	params := InvoiceQueryParams{}
+	if p != nil {
+		params = *p
+	}
+	return &InvoiceIterator{it: newPageIterator(ctx, params.Page, func(ctx context.Context, page int) ([]interface{}, *MetaInformation, error) {
+		pageParams := params
+		pageParams.Page = page
+		resp, err := c.ListInvoices(ctx, &pageParams)
+		if err != nil {
+			return nil, nil, err
+		}
+		items := make([]interface{}, len(resp.Invoices))
+		for i, o := range resp.Invoices {
+			items[i] = o
+		}
+		return items, resp.MetaInformation, nil
+	}, opts)}
+}
+
+// Next advances to the next invoice, returning false when done or on error
+func (i *InvoiceIterator) Next() bool {
+	return i.it.next()
+}
+
+// Invoice is the current invoice
+func (i *InvoiceIterator) Invoice() *InvoiceShort {
+	v, _ := i.it.cur.(*InvoiceShort)
+	return v
+}
+
+// Err is the error which stopped iteration, if any
+func (i *InvoiceIterator) Err() error {
+	return i.it.err
+}
+
+// Close stops iteration early
+func (i *InvoiceIterator) Close() {
+	i.it.close()
+}
+
+func (c *Client) invoiceAction(ctx context.Context, method string, id int, action string) (*InvoiceFull, error) {
+
+	resp := &InvoiceResp{}
+	err := c.action(ctx, method, fmt.Sprintf("invoices/%d/%s", id, action), resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Invoice, nil
+}
+
+// BookkeepInvoice bookkeeps an invoice
+func (c *Client) BookkeepInvoice(ctx context.Context, id int) (*InvoiceFull, error) {
+	return c.invoiceAction(ctx, "PUT", id, "bookkeep")
+}
+
+// CancelInvoice cancels an invoice
+func (c *Client) CancelInvoice(ctx context.Context, id int) (*InvoiceFull, error) {
+	return c.invoiceAction(ctx, "PUT", id, "cancel")
+}
+
+// CreditInvoice creates a credit invoice for an invoice. The returned invoice's CreditInvoiceReference points to the credit invoice
+func (c *Client) CreditInvoice(ctx context.Context, id int) (*InvoiceFull, error) {
+	return c.invoiceAction(ctx, "PUT", id, "credit")
+}
+
+// EmailInvoice sends an invoice as email using the invoice's EmailInformation
+func (c *Client) EmailInvoice(ctx context.Context, id int) (*InvoiceFull, error) {
+	return c.invoiceAction(ctx, "GET", id, "email")
+}
+
+// ExternalPrintInvoice marks an invoice as sent without sending it
+func (c *Client) ExternalPrintInvoice(ctx context.Context, id int) (*InvoiceFull, error) {
+	return c.invoiceAction(ctx, "PUT", id, "externalprint")
+}
+
+// WarehouseReadyInvoice marks an invoice as ready for the warehouse
+func (c *Client) WarehouseReadyInvoice(ctx context.Context, id int) (*InvoiceFull, error) {
+	return c.invoiceAction(ctx, "PUT", id, "warehouseready")
+}
+
+// PrintInvoice gets the invoice pdf and marks it as sent
+func (c *Client) PrintInvoice(ctx context.Context, id int) ([]byte, error) {
+	return c.getDocument(ctx, fmt.Sprintf("invoices/%d/print", id), true)
+}
+
+// PreviewInvoice gets the invoice pdf without marking it as sent
+func (c *Client) PreviewInvoice(ctx context.Context, id int) ([]byte, error) {
+	return c.getDocument(ctx, fmt.Sprintf("invoices/%d/preview", id), false)
+}
+
+// PrintInvoicePDF streams the invoice pdf and marks it as sent
+func (c *Client) PrintInvoicePDF(ctx context.Context, id int) (*Document, error) {
+	return c.getDocumentStream(ctx, fmt.Sprintf("invoices/%d/print", id), nil, fmt.Sprintf("invoice-%d.pdf", id), true)
+}
+
+// PreviewInvoicePDF streams the invoice pdf without marking it as sent
+func (c *Client) PreviewInvoicePDF(ctx context.Context, id int) (*Document, error) {
+	return c.getDocumentStream(ctx, fmt.Sprintf("invoices/%d/preview", id), nil, fmt.Sprintf("invoice-%d.pdf", id), false)
+}
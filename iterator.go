@@ -0,0 +1,160 @@
+package fortnox
+
+import (
+	"context"
+)
+
+// IteratorOption customises list iterators
+type IteratorOption func(o *iteratorOptions)
+
+type iteratorOptions struct {
+	concurrency int
+}
+
+// WithPageConcurrency fetches up to n pages at a time. Items are still returned in order.
+func WithPageConcurrency(n int) IteratorOption {
+	return func(o *iteratorOptions) {
+		o.concurrency = n
+	}
+}
+
+// pageFetcher fetches a single page of a list endpoint
+type pageFetcher func(ctx context.Context, page int) ([]interface{}, *MetaInformation, error)
+
+type pageResult struct {
+	items []interface{}
+	err   error
+}
+
+// pageIterator walks @CurrentPage to @TotalPages, the typed iterators wrap this
+type pageIterator struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	fetch  pageFetcher
+	opts   iteratorOptions
+
+	started    bool
+	page       int
+	firstPage  int
+	totalPages int
+
+	// used when fetching concurrently, one slot per page after the first
+	prefetched []chan pageResult
+	window     chan struct{}
+
+	items  []interface{}
+	pos    int
+	cur    interface{}
+	err    error
+	closed bool
+}
+
+func newPageIterator(ctx context.Context, firstPage int, fetch pageFetcher, optFuncs []IteratorOption) *pageIterator {
+	opts := iteratorOptions{concurrency: 1}
+	for _, f := range optFuncs {
+		f(&opts)
+	}
+	if firstPage < 1 {
+		firstPage = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return &pageIterator{
+		ctx:       ctx,
+		cancel:    cancel,
+		fetch:     fetch,
+		opts:      opts,
+		firstPage: firstPage,
+	}
+}
+
+func (it *pageIterator) next() bool {
+	for {
+		if it.err != nil || it.closed {
+			return false
+		}
+		if it.pos < len(it.items) {
+			it.cur = it.items[it.pos]
+			it.pos++
+			return true
+		}
+		if it.started && it.page >= it.totalPages {
+			it.close()
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+
+		items, err := it.nextPage()
+		if err != nil {
+			it.err = err
+			it.cancel()
+			return false
+		}
+		it.items = items
+		it.pos = 0
+	}
+}
+
+func (it *pageIterator) nextPage() ([]interface{}, error) {
+	if !it.started {
+		items, meta, err := it.fetch(it.ctx, it.firstPage)
+		if err != nil {
+			return nil, err
+		}
+		it.started = true
+		it.page = it.firstPage
+		it.totalPages = it.firstPage
+		if meta != nil && meta.TotalPages > it.totalPages {
+			it.totalPages = meta.TotalPages
+		}
+		if it.opts.concurrency > 1 && it.totalPages > it.page {
+			it.prefetch()
+		}
+		return items, nil
+	}
+
+	it.page++
+	if it.prefetched == nil {
+		items, _, err := it.fetch(it.ctx, it.page)
+		return items, err
+	}
+
+	select {
+	case res := <-it.prefetched[it.page-it.firstPage-1]:
+		<-it.window
+		return res.items, res.err
+	case <-it.ctx.Done():
+		return nil, it.ctx.Err()
+	}
+}
+
+// prefetch fetches the remaining pages in the background, keeping at most concurrency pages in flight or unread
+func (it *pageIterator) prefetch() {
+	from := it.page + 1
+	it.prefetched = make([]chan pageResult, it.totalPages-it.page)
+	for i := range it.prefetched {
+		it.prefetched[i] = make(chan pageResult, 1)
+	}
+	it.window = make(chan struct{}, it.opts.concurrency)
+
+	go func() {
+		for page := from; page <= it.totalPages; page++ {
+			select {
+			case it.window <- struct{}{}:
+			case <-it.ctx.Done():
+				return
+			}
+			go func(page int) {
+				items, _, err := it.fetch(it.ctx, page)
+				it.prefetched[page-from] <- pageResult{items: items, err: err}
+			}(page)
+		}
+	}()
+}
+
+func (it *pageIterator) close() {
+	it.closed = true
+	it.cancel()
+}
@@ -0,0 +1,89 @@
+package fortnox
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+func newPagedOrdersServer(totalPages, perPage int, calls *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		fmt.Fprint(w, `{"Orders":[`)
+		for i := 0; i < perPage; i++ {
+			if i > 0 {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"DocumentNumber":"%d"}`, (page-1)*perPage+i+1)
+		}
+		fmt.Fprintf(w, `],"MetaInformation":{"@CurrentPage":%d,"@TotalPages":%d,"@TotalResources":%d}}`, page, totalPages, totalPages*perPage)
+	}))
+}
+
+func TestClient_Orders(t *testing.T) {
+	for _, concurrency := range []int{1, 3} {
+		var calls int32
+		ts := newPagedOrdersServer(5, 4, &calls)
+
+		c := NewClient(WithURLOpts(ts.URL+"/"), WithRateLimitOpts(0, 0, 0))
+		it := c.Orders(context.Background(), nil, WithPageConcurrency(concurrency))
+
+		n := 0
+		for it.Next() {
+			n++
+			if it.Order().DocumentNumber != strconv.Itoa(n) {
+				t.Fatalf("out of order, expected %d got %s", n, it.Order().DocumentNumber)
+			}
+		}
+		if it.Err() != nil {
+			t.Fatal(it.Err())
+		}
+		if n != 20 {
+			t.Fatal("expected 20 orders, got", n)
+		}
+		if calls != 5 {
+			t.Fatal("expected 5 calls, got", calls)
+		}
+		ts.Close()
+	}
+}
+
+func TestClient_OrdersCancel(t *testing.T) {
+	var calls int32
+	ts := newPagedOrdersServer(5, 4, &calls)
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := NewClient(WithURLOpts(ts.URL+"/"), WithRateLimitOpts(0, 0, 0))
+	it := c.Orders(ctx, nil, WithPageConcurrency(2))
+
+	n := 0
+	for it.Next() {
+		n++
+		if n == 6 {
+			cancel()
+		}
+	}
+	if it.Err() != context.Canceled {
+		t.Fatal("expected cancel, got", it.Err())
+	}
+	if n != 8 {
+		t.Fatal("expected to finish current page, got", n)
+	}
+
+	it = c.Orders(context.Background(), nil)
+	it.Next()
+	it.Close()
+	if it.Next() || it.Err() != nil {
+		t.Fatal("expected closed iterator to stop without error")
+	}
+}
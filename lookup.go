@@ -0,0 +1,285 @@
+package fortnox
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultLookupTTL is how long a Lookup caches a register before fetching it again
+const DefaultLookupTTL = 15 * time.Minute
+
+// InvalidCodeError is returned by Lookup.ValidateOrder for codes missing from their register. It matches ErrValidation
+type InvalidCodeError struct {
+	Field string
+	Code  string
+}
+
+// Error pretty print error
+func (e InvalidCodeError) Error() string {
+	return fmt.Sprintf("invalid %s %q", e.Field, e.Code)
+}
+
+// Is makes the error match ErrValidation
+func (e InvalidCodeError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// unknownCodeError is a code missing from its register, as opposed to a register which couldn't be fetched
+type unknownCodeError struct {
+	register string
+	code     string
+}
+
+func (e unknownCodeError) Error() string {
+	return fmt.Sprintf("unknown %s %q", e.register, e.code)
+}
+
+// Is makes the error match ErrNotFound
+func (e unknownCodeError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// Lookup caches the reference registers, units, terms of payment, terms of delivery, ways of delivery,
+// currencies and modes of payment, so codes can be validated without a request each. Safe for concurrent use
+type Lookup struct {
+	client *Client
+	ttl    time.Duration
+	clock  clock
+
+	mu        sync.Mutex
+	registers map[string]*lookupRegister
+}
+
+type lookupRegister struct {
+	// held while fetching, so concurrent lookups wait for one fetch
+	mu      sync.Mutex
+	items   map[string]interface{}
+	fetched time.Time
+}
+
+// NewLookup creates a lookup, ttl defaults to DefaultLookupTTL
+func NewLookup(c *Client, ttl time.Duration) *Lookup {
+	if ttl <= 0 {
+		ttl = DefaultLookupTTL
+	}
+	return &Lookup{
+		client:    c,
+		ttl:       ttl,
+		clock:     realClock{},
+		registers: map[string]*lookupRegister{},
+	}
+}
+
+// Invalidate drops all cached registers, e.g. after creating a unit
+func (l *Lookup) Invalidate() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.registers = map[string]*lookupRegister{}
+}
+
+// registerPage fetches one page of a register into items
+type registerPage func(ctx context.Context, page int, items map[string]interface{}) (*MetaInformation, error)
+
+func (l *Lookup) get(ctx context.Context, register string, code string, fetch registerPage) (interface{}, error) {
+	l.mu.Lock()
+	reg, ok := l.registers[register]
+	if !ok {
+		reg = &lookupRegister{}
+		l.registers[register] = reg
+	}
+	l.mu.Unlock()
+
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	if reg.items == nil || l.clock.Now().Sub(reg.fetched) >= l.ttl {
+		items, err := fetchRegister(ctx, fetch)
+		if err != nil {
+			return nil, err
+		}
+		reg.items = items
+		reg.fetched = l.clock.Now()
+	}
+
+	item, ok := reg.items[code]
+	if !ok {
+		return nil, unknownCodeError{register: register, code: code}
+	}
+	return item, nil
+}
+
+func fetchRegister(ctx context.Context, fetch registerPage) (map[string]interface{}, error) {
+	items := map[string]interface{}{}
+	for page := 1; ; page++ {
+		meta, err := fetch(ctx, page, items)
+		if err != nil {
+			return nil, err
+		}
+		if meta == nil || page >= meta.TotalPages {
+			return items, nil
+		}
+	}
+}
+
+// Unit looks up a unit by code. The error matches ErrNotFound if there is none
+func (l *Lookup) Unit(ctx context.Context, code string) (*Unit, error) {
+	item, err := l.get(ctx, "units", code, func(ctx context.Context, page int, items map[string]interface{}) (*MetaInformation, error) {
+		resp, err := l.client.ListUnits(ctx, &UnitQueryParams{Page: page})
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range resp.Units {
+			items[u.Code] = u
+		}
+		return resp.MetaInformation, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return item.(*Unit), nil
+}
+
+// TermsOfPayment looks up terms of payment by code. The error matches ErrNotFound if there is none
+func (l *Lookup) TermsOfPayment(ctx context.Context, code string) (*TermsOfPayment, error) {
+	item, err := l.get(ctx, "terms of payment", code, func(ctx context.Context, page int, items map[string]interface{}) (*MetaInformation, error) {
+		resp, err := l.client.ListTermsOfPayments(ctx, &TermsOfPaymentQueryParams{Page: page})
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range resp.TermsOfPayments {
+			items[t.Code] = t
+		}
+		return resp.MetaInformation, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return item.(*TermsOfPayment), nil
+}
+
+// TermsOfDelivery looks up terms of delivery by code. The error matches ErrNotFound if there is none
+func (l *Lookup) TermsOfDelivery(ctx context.Context, code string) (*TermsOfDelivery, error) {
+	item, err := l.get(ctx, "terms of delivery", code, func(ctx context.Context, page int, items map[string]interface{}) (*MetaInformation, error) {
+		resp, err := l.client.ListTermsOfDeliveries(ctx, &TermsOfDeliveryQueryParams{Page: page})
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range resp.TermsOfDeliveries {
+			items[t.Code] = t
+		}
+		return resp.MetaInformation, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return item.(*TermsOfDelivery), nil
+}
+
+// WayOfDelivery looks up a way of delivery by code. The error matches ErrNotFound if there is none
+func (l *Lookup) WayOfDelivery(ctx context.Context, code string) (*WayOfDelivery, error) {
+	item, err := l.get(ctx, "way of delivery", code, func(ctx context.Context, page int, items map[string]interface{}) (*MetaInformation, error) {
+		resp, err := l.client.ListWayOfDeliveries(ctx, &WayOfDeliveryQueryParams{Page: page})
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range resp.WayOfDeliveries {
+			items[w.Code] = w
+		}
+		return resp.MetaInformation, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return item.(*WayOfDelivery), nil
+}
+
+// Currency looks up a currency by code. The error matches ErrNotFound if there is none
+func (l *Lookup) Currency(ctx context.Context, code string) (*Currency, error) {
+	item, err := l.get(ctx, "currency", code, func(ctx context.Context, page int, items map[string]interface{}) (*MetaInformation, error) {
+		resp, err := l.client.ListCurrencies(ctx, &CurrencyQueryParams{Page: page})
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range resp.Currencies {
+			items[c.Code] = c
+		}
+		return resp.MetaInformation, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return item.(*Currency), nil
+}
+
+// ModeOfPayment looks up a mode of payment by code. The error matches ErrNotFound if there is none
+func (l *Lookup) ModeOfPayment(ctx context.Context, code string) (*ModeOfPayment, error) {
+	item, err := l.get(ctx, "mode of payment", code, func(ctx context.Context, page int, items map[string]interface{}) (*MetaInformation, error) {
+		resp, err := l.client.ListModesOfPayments(ctx, &ModeOfPaymentQueryParams{Page: page})
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range resp.ModesOfPayments {
+			items[m.Code] = m
+		}
+		return resp.MetaInformation, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return item.(*ModeOfPayment), nil
+}
+
+// ValidateOrder checks the order's currency, terms of payment, terms of delivery, way of delivery and row units
+// against their registers. Unknown codes give an InvalidCodeError
+func (l *Lookup) ValidateOrder(ctx context.Context, o *CreateOrder) error {
+	check := func(field string, code string, lookup func(ctx context.Context, code string) error) error {
+		if code == "" {
+			return nil
+		}
+		err := lookup(ctx, code)
+		if _, ok := err.(unknownCodeError); ok {
+			return InvalidCodeError{Field: field, Code: code}
+		}
+		return err
+	}
+
+	unit := func(ctx context.Context, code string) error { _, err := l.Unit(ctx, code); return err }
+	currency := func(ctx context.Context, code string) error { _, err := l.Currency(ctx, code); return err }
+	termsOfPayment := func(ctx context.Context, code string) error { _, err := l.TermsOfPayment(ctx, code); return err }
+	termsOfDelivery := func(ctx context.Context, code string) error { _, err := l.TermsOfDelivery(ctx, code); return err }
+	wayOfDelivery := func(ctx context.Context, code string) error { _, err := l.WayOfDelivery(ctx, code); return err }
+
+	var termsOfPaymentCode string
+	if o.TermsOfPayment != nil {
+		termsOfPaymentCode = string(*o.TermsOfPayment)
+	}
+	if err := check("Currency", deref(o.Currency), currency); err != nil {
+		return err
+	}
+	if err := check("TermsOfPayment", termsOfPaymentCode, termsOfPayment); err != nil {
+		return err
+	}
+	if err := check("TermsOfDelivery", deref(o.TermsOfDelivery), termsOfDelivery); err != nil {
+		return err
+	}
+	if err := check("WayOfDelivery", deref(o.WayOfDelivery), wayOfDelivery); err != nil {
+		return err
+	}
+	for _, row := range o.OrderRows {
+		if row == nil {
+			continue
+		}
+		if err := check("Unit", deref(row.Unit), unit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,145 @@
+package fortnox
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLookup(t *testing.T) {
+	var unitRequests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/units":
+			atomic.AddInt32(&unitRequests, 1)
+			fmt.Fprint(w, `{"Units":[{"Code":"st","Description":"Styck"},{"Code":"h","Description":"Timme"}],"MetaInformation":{"@TotalPages":1,"@CurrentPage":1}}`)
+		case "/currencies":
+			fmt.Fprint(w, `{"Currencies":[{"Code":"SEK"},{"Code":"EUR"}],"MetaInformation":{"@TotalPages":1,"@CurrentPage":1}}`)
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	clock := newFakeClock()
+	l := NewLookup(NewClient(WithURLOpts(ts.URL+"/"), WithRateLimitOpts(0, 0, 0)), time.Minute)
+	l.clock = clock
+
+	u, err := l.Unit(ctx, "h")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Description != "Timme" {
+		t.Fatalf("unexpected unit %+v", u)
+	}
+	if _, err := l.Unit(ctx, "kg"); !IsNotFound(err) {
+		t.Fatal("expected not found, got", err)
+	}
+	if n := atomic.LoadInt32(&unitRequests); n != 1 {
+		t.Fatal("expected units to be cached, got requests:", n)
+	}
+
+	clock.Advance(time.Minute)
+	if _, err := l.Unit(ctx, "st"); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&unitRequests); n != 2 {
+		t.Fatal("expected units to be fetched again after ttl, got requests:", n)
+	}
+
+	eur, kg, st := "EUR", "kg", "st"
+	err = l.ValidateOrder(ctx, &CreateOrder{Currency: &eur, OrderRows: []*CreateOrderRow{{Unit: &st}, {Unit: &kg}}})
+	if e, ok := err.(InvalidCodeError); !ok || e.Field != "Unit" || e.Code != "kg" {
+		t.Fatalf("unexpected error %#v", err)
+	}
+	if !IsValidation(err) {
+		t.Fatal("expected validation error")
+	}
+
+	if err := l.ValidateOrder(ctx, &CreateOrder{Currency: &eur, OrderRows: []*CreateOrderRow{{Unit: &st}}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// a register which can't be fetched isn't an invalid code
+	express := "express"
+	err = l.ValidateOrder(ctx, &CreateOrder{WayOfDelivery: &express})
+	if _, ok := err.(InvalidCodeError); ok || IsValidation(err) {
+		t.Fatalf("expected the fetch error, got %#v", err)
+	}
+	if !IsNotFound(err) {
+		t.Fatal("expected the list endpoint's not found, got", err)
+	}
+}
+
+func TestLookup_FetchesEveryPage(t *testing.T) {
+	api := newStubAPI(t, map[string]string{
+		"GET termsofpayments": `{"TermsOfPayments":[{"Code":"30","NumberOfDays":30}],"MetaInformation":{"@CurrentPage":1,"@TotalPages":2,"@TotalResources":2}}`,
+	})
+	defer api.Close()
+
+	l := NewLookup(api.client(), time.Minute)
+	if _, err := l.TermsOfPayment(context.Background(), "30"); err != nil {
+		t.Fatal(err)
+	}
+	reqs := api.received("GET termsofpayments")
+	if len(reqs) != 2 || reqs[1].Query.Get("page") != "2" {
+		t.Fatalf("expected both pages to be fetched, got %+v", reqs)
+	}
+}
+
+func TestClient_Registers(t *testing.T) {
+	api := newStubAPI(t, map[string]string{
+		"GET units/st":              `{"Unit":{"Code":"st","Description":"Styck"}}`,
+		"POST units/":               `{"Unit":{"Code":"kg","Description":"Kilogram"}}`,
+		"DELETE units/kg":           ``,
+		"PUT termsofpayments/30":    `{"TermsOfPayment":{"Code":"30","NumberOfDays":45}}`,
+		"GET termsofdeliveries/FVL": `{"TermsOfDelivery":{"Code":"FVL","Description":"Fritt vårt lager"}}`,
+		"GET wayofdeliveries/P":     `{"WayOfDelivery":{"Code":"P","Description":"Post"}}`,
+		"GET currencies/EUR":        `{"Currency":{"Code":"EUR","SellRate":"10.50","Unit":"1"}}`,
+		"GET modesofpayments/BG":    `{"ModeOfPayment":{"Code":"BG","AccountNumber":1930}}`,
+		"DELETE modesofpayments/BG": ``,
+	})
+	defer api.Close()
+	c := api.client()
+	ctx := context.Background()
+
+	if u, err := c.GetUnit(ctx, "st"); err != nil || u.Description != "Styck" {
+		t.Fatal("unexpected unit", u, err)
+	}
+	code, desc := "kg", "Kilogram"
+	if u, err := c.CreateUnit(ctx, &CreateUnit{Code: &code, Description: &desc}); err != nil || u.Code != "kg" {
+		t.Fatal("unexpected unit", u, err)
+	}
+	if err := c.DeleteUnit(ctx, "kg"); err != nil {
+		t.Fatal(err)
+	}
+
+	days := 45
+	if tp, err := c.UpdateTermsOfPayment(ctx, "30", &UpdateTermsOfPayment{NumberOfDays: &days}); err != nil || tp.NumberOfDays != 45 {
+		t.Fatal("unexpected terms of payment", tp, err)
+	}
+	if body := api.received("PUT termsofpayments/30")[0].Body; body != `{"TermsOfPayment":{"NumberOfDays":45}}`+"\n" {
+		t.Fatal("unexpected body", body)
+	}
+
+	if td, err := c.GetTermsOfDelivery(ctx, "FVL"); err != nil || td.Description != "Fritt vårt lager" {
+		t.Fatal("unexpected terms of delivery", td, err)
+	}
+	if wd, err := c.GetWayOfDelivery(ctx, "P"); err != nil || wd.Description != "Post" {
+		t.Fatal("unexpected way of delivery", wd, err)
+	}
+	if cur, err := c.GetCurrency(ctx, "EUR"); err != nil || cur.SellRate != 10.5 {
+		t.Fatal("unexpected currency", cur, err)
+	}
+	if mp, err := c.GetModeOfPayment(ctx, "BG"); err != nil || mp.AccountNumber != 1930 {
+		t.Fatal("unexpected mode of payment", mp, err)
+	}
+	if err := c.DeleteModeOfPayment(ctx, "BG"); err != nil {
+		t.Fatal(err)
+	}
+}
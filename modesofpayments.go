@@ -0,0 +1,129 @@
+package fortnox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// ModeOfPayment data type
+type ModeOfPayment struct {
+	URL                string `json:"@url"`
+	AccountNumber      Intish `json:"AccountNumber"`
+	Code               string `json:"Code"`
+	Description        string `json:"Description"`
+	DescriptionEnglish string `json:"DescriptionEnglish"`
+}
+
+// CreateModeOfPayment payload for creating modes of payment
+type CreateModeOfPayment struct {
+	AccountNumber      *int    `json:"AccountNumber,omitempty"`
+	Code               *string `json:"Code,omitempty"`
+	Description        *string `json:"Description,omitempty"`
+	DescriptionEnglish *string `json:"DescriptionEnglish,omitempty"`
+}
+
+// UpdateModeOfPayment payload for updating modes of payment
+type UpdateModeOfPayment CreateModeOfPayment
+
+// ModeOfPaymentQueryParams when listing modes of payment
+type ModeOfPaymentQueryParams struct {
+	Page   int
+	Limit  int
+	Offset int
+	Extra  map[string][]string
+}
+
+func (p ModeOfPaymentQueryParams) toValues() url.Values {
+
+	ret := make(url.Values)
+
+	if p.Limit > 0 {
+		ret["limit"] = []string{fmt.Sprintf("%d", p.Limit)}
+	}
+	if p.Offset > 0 {
+		ret["offset"] = []string{fmt.Sprintf("%d", p.Offset)}
+	}
+	if p.Page > 0 {
+		ret["page"] = []string{fmt.Sprintf("%d", p.Page)}
+	}
+	for k, vs := range p.Extra {
+		ret[k] = vs
+	}
+	return ret
+}
+
+// ListModesOfPaymentsResp Response when listing modes of payment
+type ListModesOfPaymentsResp struct {
+	ModesOfPayments []*ModeOfPayment `json:"ModesOfPayments"`
+	MetaInformation *MetaInformation `json:"MetaInformation"`
+}
+
+// ListModesOfPayments lists modes of payment
+func (c *Client) ListModesOfPayments(ctx context.Context, p *ModeOfPaymentQueryParams) (*ListModesOfPaymentsResp, error) {
+	resp := &ListModesOfPaymentsResp{}
+
+	var vals url.Values
+	if p != nil {
+		vals = p.toValues()
+	}
+
+	err := c.request(ctx, "GET", "modesofpayments", nil, vals, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ModeOfPaymentResp Response for single mode of payment
+type ModeOfPaymentResp struct {
+	ModeOfPayment ModeOfPayment `json:"ModeOfPayment"`
+}
+
+// GetModeOfPayment gets one mode of payment by code
+func (c *Client) GetModeOfPayment(ctx context.Context, code string) (*ModeOfPayment, error) {
+
+	resp := &ModeOfPaymentResp{}
+
+	err := c.request(ctx, "GET", "modesofpayments/"+url.PathEscape(code), nil, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.ModeOfPayment, nil
+}
+
+// CreateModeOfPayment creates a mode of payment
+func (c *Client) CreateModeOfPayment(ctx context.Context, modeOfPayment *CreateModeOfPayment) (*ModeOfPayment, error) {
+	resp := &ModeOfPaymentResp{}
+	err := c.request(ctx, "POST", "modesofpayments/", &struct {
+		ModeOfPayment *CreateModeOfPayment `json:"ModeOfPayment"`
+	}{
+		ModeOfPayment: modeOfPayment,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.ModeOfPayment, nil
+}
+
+// UpdateModeOfPayment updates a mode of payment
+func (c *Client) UpdateModeOfPayment(ctx context.Context, code string, modeOfPayment *UpdateModeOfPayment) (*ModeOfPayment, error) {
+	resp := &ModeOfPaymentResp{}
+	err := c.request(ctx, "PUT", "modesofpayments/"+url.PathEscape(code), &struct {
+		ModeOfPayment *UpdateModeOfPayment `json:"ModeOfPayment"`
+	}{
+		ModeOfPayment: modeOfPayment,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.ModeOfPayment, nil
+}
+
+// DeleteModeOfPayment deletes one mode of payment
+func (c *Client) DeleteModeOfPayment(ctx context.Context, code string) error {
+	return c.deleteResource(ctx, "modesofpayments/"+url.PathEscape(code))
+}
@@ -0,0 +1,356 @@
+package fortnox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// OfferShort data type
+type OfferShort struct {
+	URL            string  `json:"@url"`
+	Cancelled      bool    `json:"Cancelled"`
+	Currency       string  `json:"Currency"`
+	CustomerName   string  `json:"CustomerName"`
+	CustomerNumber string  `json:"CustomerNumber"`
+	DocumentNumber string  `json:"DocumentNumber"`
+	OfferDate      Date    `json:"OfferDate"`
+	Project        string  `json:"Project"`
+	Sent           bool    `json:"Sent"`
+	Total          float64 `json:"Total"`
+}
+
+// OfferRow data type
+type OfferRow struct {
+	AccountNumber          int      `json:"AccountNumber"`
+	ArticleNumber          string   `json:"ArticleNumber"`
+	ContributionPercent    Floatish `json:"ContributionPercent,omitempty"`
+	ContributionValue      Floatish `json:"ContributionValue,omitempty"`
+	CostCenter             string   `json:"CostCenter"`
+	Description            string   `json:"Description"`
+	Discount               float64  `json:"Discount"`
+	DiscountType           string   `json:"DiscountType"`
+	HouseWork              bool     `json:"HouseWork"`
+	HouseWorkHoursToReport int      `json:"HouseWorkHoursToReport"`
+	HouseWorkType          string   `json:"HouseWorkType"`
+	Price                  float64  `json:"Price"`
+	Project                string   `json:"Project"`
+	Quantity               string   `json:"Quantity"`
+	Total                  float64  `json:"Total"`
+	Unit                   string   `json:"Unit"`
+	VAT                    float64  `json:"VAT"`
+}
+
+// CreateOfferRow payload for offer rows when creating new offer. Pointers since most fields are not required.
+type CreateOfferRow struct {
+	AccountNumber          *int64   `json:"AccountNumber,omitempty"`
+	ArticleNumber          *string  `json:"ArticleNumber,omitempty"`
+	CostCenter             *string  `json:"CostCenter,omitempty"`
+	Description            *string  `json:"Description,omitempty"`
+	Discount               *float64 `json:"Discount,omitempty"`
+	DiscountType           *string  `json:"DiscountType,omitempty"`
+	HouseWork              *bool    `json:"HouseWork,omitempty"`
+	HouseWorkHoursToReport *int64   `json:"HouseWorkHoursToReport,omitempty"`
+	HouseWorkType          *string  `json:"HouseWorkType,omitempty"`
+	Price                  *float64 `json:"Price,omitempty"`
+	Project                *string  `json:"Project,omitempty"`
+	Quantity               *string  `json:"Quantity,omitempty"`
+	Unit                   *string  `json:"Unit,omitempty"`
+	VAT                    *float64 `json:"VAT,omitempty"`
+}
+
+// CreateOffer payload for creating offers
+type CreateOffer struct {
+	AdministrationFee *float64          `json:"AdministrationFee,omitempty"`
+	Address1          *string           `json:"Address1,omitempty"`
+	Address2          *string           `json:"Address2,omitempty"`
+	City              *string           `json:"City,omitempty"`
+	Comments          *string           `json:"Comments,omitempty"`
+	CopyRemarks       *bool             `json:"CopyRemarks,omitempty"`
+	Country           *string           `json:"Country,omitempty"`
+	CostCenter        *string           `json:"CostCenter,omitempty"`
+	Currency          *string           `json:"Currency,omitempty"`
+	CurrencyRate      *float64          `json:"CurrencyRate,omitempty"`
+	CurrencyUnit      *float64          `json:"CurrencyUnit,omitempty"`
+	CustomerName      *string           `json:"CustomerName,omitempty"`
+	CustomerNumber    *string           `json:"CustomerNumber,omitempty"`
+	DeliveryAddress1  *string           `json:"DeliveryAddress1,omitempty"`
+	DeliveryAddress2  *string           `json:"DeliveryAddress2,omitempty"`
+	DeliveryCity      *string           `json:"DeliveryCity,omitempty"`
+	DeliveryCountry   *string           `json:"DeliveryCountry,omitempty"`
+	DeliveryDate      *string           `json:"DeliveryDate,omitempty"`
+	DeliveryName      *string           `json:"DeliveryName,omitempty"`
+	DeliveryZipCode   *string           `json:"DeliveryZipCode,omitempty"`
+	DocumentNumber    *Intish           `json:"DocumentNumber,omitempty"`
+	EmailInformation  *EmailInformation `json:"EmailInformation,omitempty"`
+	ExpireDate        *string           `json:"ExpireDate,omitempty"`
+	Freight           *float64          `json:"Freight,omitempty"`
+	Language          *string           `json:"Language,omitempty"`
+	OfferDate         *string           `json:"OfferDate,omitempty"`
+	OfferRows         []*CreateOfferRow `json:"OfferRows,omitempty"`
+	OurReference      *string           `json:"OurReference,omitempty"`
+	Phone1            *string           `json:"Phone1,omitempty"`
+	Phone2            *string           `json:"Phone2,omitempty"`
+	PriceList         *string           `json:"PriceList,omitempty"`
+	PrintTemplate     *string           `json:"PrintTemplate,omitempty"`
+	Project           *string           `json:"Project,omitempty"`
+	Remarks           *string           `json:"Remarks,omitempty"`
+	TermsOfDelivery   *string           `json:"TermsOfDelivery,omitempty"`
+	TermsOfPayment    *StringIsh        `json:"TermsOfPayment,omitempty"`
+	VATIncluded       *bool             `json:"VATIncluded,omitempty"`
+	WayOfDelivery     *string           `json:"WayOfDelivery,omitempty"`
+	YourReference     *string           `json:"YourReference,omitempty"`
+	ZipCode           *string           `json:"ZipCode,omitempty"`
+}
+
+// UpdateOffer payload for updating offers
+type UpdateOffer CreateOffer
+
+// OfferFull data type
+type OfferFull struct {
+	URL                  string           `json:"@url"`
+	URLTaxReductionList  string           `json:"@urlTaxReductionList"`
+	AdministrationFee    float64          `json:"AdministrationFee"`
+	AdministrationFeeVAT float64          `json:"AdministrationFeeVAT,omitempty"`
+	Address1             string           `json:"Address1"`
+	Address2             string           `json:"Address2"`
+	BasisTaxReduction    float64          `json:"BasisTaxReduction,omitempty"`
+	Cancelled            bool             `json:"Cancelled,omitempty"`
+	City                 string           `json:"City"`
+	Comments             string           `json:"Comments"`
+	ContributionPercent  Floatish         `json:"ContributionPercent,omitempty"`
+	ContributionValue    Floatish         `json:"ContributionValue,omitempty"`
+	CopyRemarks          bool             `json:"CopyRemarks"`
+	Country              string           `json:"Country"`
+	CostCenter           string           `json:"CostCenter"`
+	Currency             string           `json:"Currency"`
+	CurrencyRate         Floatish         `json:"CurrencyRate"`
+	CurrencyUnit         float64          `json:"CurrencyUnit"`
+	CustomerName         string           `json:"CustomerName"`
+	CustomerNumber       string           `json:"CustomerNumber"`
+	DeliveryAddress1     string           `json:"DeliveryAddress1"`
+	DeliveryAddress2     string           `json:"DeliveryAddress2"`
+	DeliveryCity         string           `json:"DeliveryCity"`
+	DeliveryCountry      string           `json:"DeliveryCountry"`
+	DeliveryDate         Date             `json:"DeliveryDate"`
+	DeliveryName         string           `json:"DeliveryName"`
+	DeliveryZipCode      string           `json:"DeliveryZipCode"`
+	DocumentNumber       Intish           `json:"DocumentNumber"`
+	EmailInformation     EmailInformation `json:"EmailInformation"`
+	ExpireDate           Date             `json:"ExpireDate"`
+	Freight              float64          `json:"Freight"`
+	FreightVAT           float64          `json:"FreightVAT"`
+	Gross                float64          `json:"Gross"`
+	HouseWork            bool             `json:"HouseWork"`
+	InvoiceReference     Intish           `json:"InvoiceReference"`
+	Language             string           `json:"Language"`
+	Net                  float64          `json:"Net"`
+	NotCompleted         bool             `json:"NotCompleted"`
+	OfferDate            Date             `json:"OfferDate"`
+	OfferRows            []OfferRow       `json:"OfferRows"`
+	OrderReference       Intish           `json:"OrderReference"`
+	OrganisationNumber   string           `json:"OrganisationNumber"`
+	OurReference         string           `json:"OurReference"`
+	Phone1               string           `json:"Phone1"`
+	Phone2               string           `json:"Phone2"`
+	PriceList            string           `json:"PriceList"`
+	PrintTemplate        string           `json:"PrintTemplate"`
+	Project              string           `json:"Project"`
+	Remarks              string           `json:"Remarks"`
+	RoundOff             float64          `json:"RoundOff"`
+	Sent                 bool             `json:"Sent"`
+	TaxReduction         float64          `json:"TaxReduction"`
+	TermsOfDelivery      string           `json:"TermsOfDelivery"`
+	TermsOfPayment       StringIsh        `json:"TermsOfPayment"`
+	Total                float64          `json:"Total"`
+	TotalToPay           float64          `json:"TotalToPay"`
+	TotalVAT             float64          `json:"TotalVAT,omitempty"`
+	VATIncluded          bool             `json:"VATIncluded"`
+	WayOfDelivery        string           `json:"WayOfDelivery"`
+	YourReference        string           `json:"YourReference"`
+	ZipCode              string           `json:"ZipCode"`
+}
+
+// OfferQueryParams when searching offers, see OrderQueryParams
+type OfferQueryParams struct {
+	LastModified      time.Time
+	FinancialYear     int
+	FinancialYearDate string
+	FromDate          string
+	ToDate            string
+	Page              int
+	Limit             int
+	Offset            int
+	Extra             map[string][]string
+}
+
+func (p OfferQueryParams) toValues() url.Values {
+
+	ret := make(url.Values)
+
+	if !p.LastModified.IsZero() {
+		ret["lastmodified"] = []string{p.LastModified.Format(TimeFormat)}
+	}
+	if p.FinancialYear > 0 {
+		ret["financialyear"] = []string{fmt.Sprintf("%d", p.FinancialYear)}
+	}
+	if len(p.FinancialYearDate) > 0 {
+		ret["financialyeardate"] = []string{p.FinancialYearDate}
+	}
+	if len(p.FromDate) > 0 {
+		ret["fromdate"] = []string{p.FromDate}
+	}
+	if len(p.ToDate) > 0 {
+		ret["todate"] = []string{p.ToDate}
+	}
+	if p.Limit > 0 {
+		ret["limit"] = []string{fmt.Sprintf("%d", p.Limit)}
+	}
+	if p.Offset > 0 {
+		ret["offset"] = []string{fmt.Sprintf("%d", p.Offset)}
+	}
+	if p.Page > 0 {
+		ret["page"] = []string{fmt.Sprintf("%d", p.Page)}
+	}
+	for k, vs := range p.Extra {
+		ret[k] = vs
+	}
+	return ret
+}
+
+// ListOffersResp Response when listing offers
+type ListOffersResp struct {
+	Offers          []*OfferShort    `json:"Offers"`
+	MetaInformation *MetaInformation `json:"MetaInformation"`
+}
+
+// ListOffers or search offers
+func (c *Client) ListOffers(ctx context.Context, p *OfferQueryParams) (*ListOffersResp, error) {
+
+	resp := &ListOffersResp{}
+
+	var vals url.Values
+	if p != nil {
+		vals = p.toValues()
+	}
+
+	err := c.request(ctx, "GET", "offers", nil, vals, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// An OfferResp is the json response for singular offer resources
+type OfferResp struct {
+	Offer OfferFull `json:"Offer"`
+}
+
+// GetOffer gets one offer by id
+func (c *Client) GetOffer(ctx context.Context, id int) (*OfferFull, error) {
+
+	resp := &OfferResp{}
+	err := c.request(ctx, "GET", fmt.Sprintf("offers/%d", id), nil, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Offer, nil
+}
+
+// CreateOffer creates an offer
+func (c *Client) CreateOffer(ctx context.Context, offer *CreateOffer) (*OfferFull, error) {
+	resp := &OfferResp{}
+	err := c.request(ctx, "POST", "offers/", &struct {
+		Offer *CreateOffer `json:"Offer"`
+	}{
+		Offer: offer,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Offer, nil
+}
+
+// UpdateOffer updates an offer
+func (c *Client) UpdateOffer(ctx context.Context, id int, offer *UpdateOffer) (*OfferFull, error) {
+
+	resp := &OfferResp{}
+	err := c.request(ctx, "PUT", fmt.Sprintf("offers/%d", id), &struct {
+		Offer *UpdateOffer `json:"Offer"`
+	}{
+		Offer: offer,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Offer, nil
+}
+
+func (c *Client) offerAction(ctx context.Context, method string, id int, action string) (*OfferFull, error) {
+
+	resp := &OfferResp{}
+	err := c.action(ctx, method, fmt.Sprintf("offers/%d/%s", id, action), resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Offer, nil
+}
+
+// CreateOrderFromOffer converts an offer into an order, returning the new order
+func (c *Client) CreateOrderFromOffer(ctx context.Context, id int) (*OrderFull, error) {
+
+	resp := &OrderResp{}
+	err := c.action(ctx, "PUT", fmt.Sprintf("offers/%d/createorder", id), resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Order, nil
+}
+
+// CreateInvoiceFromOffer converts an offer into an invoice, returning the new invoice
+func (c *Client) CreateInvoiceFromOffer(ctx context.Context, id int) (*InvoiceFull, error) {
+
+	resp := &InvoiceResp{}
+	err := c.action(ctx, "PUT", fmt.Sprintf("offers/%d/createinvoice", id), resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Invoice, nil
+}
+
+// CancelOffer cancels an offer
+func (c *Client) CancelOffer(ctx context.Context, id int) (*OfferFull, error) {
+	return c.offerAction(ctx, "PUT", id, "cancel")
+}
+
+// EmailOffer sends an offer as email using the offer's EmailInformation
+func (c *Client) EmailOffer(ctx context.Context, id int) (*OfferFull, error) {
+	return c.offerAction(ctx, "GET", id, "email")
+}
+
+// PrintOffer gets the offer pdf and marks it as sent
+func (c *Client) PrintOffer(ctx context.Context, id int) ([]byte, error) {
+	return c.getDocument(ctx, fmt.Sprintf("offers/%d/print", id), true)
+}
+
+// PreviewOffer gets the offer pdf without marking it as sent
+func (c *Client) PreviewOffer(ctx context.Context, id int) ([]byte, error) {
+	return c.getDocument(ctx, fmt.Sprintf("offers/%d/preview", id), false)
+}
+
+// PrintOfferPDF streams the offer pdf and marks it as sent
+func (c *Client) PrintOfferPDF(ctx context.Context, id int) (*Document, error) {
+	return c.getDocumentStream(ctx, fmt.Sprintf("offers/%d/print", id), nil, fmt.Sprintf("offer-%d.pdf", id), true)
+}
+
+// PreviewOfferPDF streams the offer pdf without marking it as sent
+func (c *Client) PreviewOfferPDF(ctx context.Context, id int) (*Document, error) {
+	return c.getDocumentStream(ctx, fmt.Sprintf("offers/%d/preview", id), nil, fmt.Sprintf("offer-%d.pdf", id), false)
+}
@@ -0,0 +1,66 @@
+package fortnox
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_Offers(t *testing.T) {
+	api := newStubAPI(t, map[string]string{
+		"GET offers":                 `{"Offers":[{"DocumentNumber":"7","CustomerNumber":"1","OfferDate":"2020-03-01","Total":125}],"MetaInformation":{"@CurrentPage":1,"@TotalPages":1,"@TotalResources":1}}`,
+		"POST offers/":               `{"Offer":{"DocumentNumber":"7","CustomerNumber":"1","OfferRows":[{"Description":"Desc Text"}]}}`,
+		"PUT offers/7":               `{"Offer":{"DocumentNumber":"7","CustomerNumber":"1","DeliveryCity":"Gothenburg"}}`,
+		"PUT offers/7/createorder":   `{"Order":{"DocumentNumber":"3","OfferReference":"7","OrderRows":[{"Description":"Desc Text"}]}}`,
+		"PUT offers/7/createinvoice": `{"Invoice":{"DocumentNumber":"4","InvoiceRows":[{"Description":"Desc Text"}]}}`,
+	})
+	defer api.Close()
+	c := api.client()
+	ctx := context.Background()
+
+	list, err := c.ListOffers(ctx, &OfferQueryParams{LastModified: time.Date(2020, 3, 1, 12, 30, 0, 0, time.UTC), Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Offers) != 1 || list.Offers[0].DocumentNumber != "7" || list.Offers[0].OfferDate.String() != "2020-03-01" {
+		t.Fatalf("unexpected offers %+v", list.Offers)
+	}
+	if q := api.received("GET offers")[0].Query; q.Get("lastmodified") != "2020-03-01 12:30" || q.Get("limit") != "10" {
+		t.Fatal("unexpected query", q)
+	}
+
+	one, desc, gbg := "1", "Desc Text", "Gothenburg"
+	offer, err := c.CreateOffer(ctx, &CreateOffer{CustomerNumber: &one, OfferRows: []*CreateOfferRow{{Description: &desc}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body := api.received("POST offers/")[0].Body; body != `{"Offer":{"CustomerNumber":"1","OfferRows":[{"Description":"Desc Text"}]}}`+"\n" {
+		t.Fatal("unexpected body", body)
+	}
+
+	offer, err = c.UpdateOffer(ctx, int(offer.DocumentNumber), &UpdateOffer{DeliveryCity: &gbg})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if offer.DeliveryCity != gbg {
+		t.Fatal("unexpected delivery city", offer.DeliveryCity)
+	}
+
+	order, err := c.CreateOrderFromOffer(ctx, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if order.DocumentNumber != 3 || order.OfferReference != 7 || len(order.OrderRows) != 1 {
+		t.Fatalf("unexpected order %+v", order)
+	}
+
+	// the invoice may have been created before the server failed, so it mustn't be retried
+	api.fail("PUT offers/7/createinvoice", http.StatusBadGateway)
+	if _, err := c.CreateInvoiceFromOffer(ctx, 7); !IsServerError(err) {
+		t.Fatal("expected server error, got", err)
+	}
+	if n := len(api.received("PUT offers/7/createinvoice")); n != 1 {
+		t.Fatal("expected 1 request, got", n)
+	}
+}
@@ -319,3 +319,103 @@ func (c *Client) UpdateOrder(ctx context.Context, id int, order *UpdateOrder) (*
 
 	return &resp.Order, nil
 }
+
+func (c *Client) orderAction(ctx context.Context, method string, id int, action string) (*OrderFull, error) {
+
+	resp := &OrderResp{}
+	err := c.action(ctx, method, fmt.Sprintf("orders/%d/%s", id, action), resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Order, nil
+}
+
+// CreateInvoiceFromOrder converts an order into an invoice, returning the new invoice
+func (c *Client) CreateInvoiceFromOrder(ctx context.Context, id int) (*InvoiceFull, error) {
+
+	resp := &InvoiceResp{}
+	err := c.action(ctx, "PUT", fmt.Sprintf("orders/%d/createinvoice", id), resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Invoice, nil
+}
+
+// CancelOrder cancels an order
+func (c *Client) CancelOrder(ctx context.Context, id int) (*OrderFull, error) {
+	return c.orderAction(ctx, "PUT", id, "cancel")
+}
+
+// EmailOrder sends an order confirmation as email using the order's EmailInformation
+func (c *Client) EmailOrder(ctx context.Context, id int) (*OrderFull, error) {
+	return c.orderAction(ctx, "GET", id, "email")
+}
+
+// PrintOrder gets the order confirmation pdf and marks it as sent
+func (c *Client) PrintOrder(ctx context.Context, id int) ([]byte, error) {
+	return c.getDocument(ctx, fmt.Sprintf("orders/%d/print", id), true)
+}
+
+// PreviewOrder gets the order confirmation pdf without marking it as sent
+func (c *Client) PreviewOrder(ctx context.Context, id int) ([]byte, error) {
+	return c.getDocument(ctx, fmt.Sprintf("orders/%d/preview", id), false)
+}
+
+// PrintOrderPDF streams the order confirmation pdf and marks it as sent
+func (c *Client) PrintOrderPDF(ctx context.Context, id int) (*Document, error) {
+	return c.getDocumentStream(ctx, fmt.Sprintf("orders/%d/print", id), nil, fmt.Sprintf("order-%d.pdf", id), true)
+}
+
+// PreviewOrderPDF streams the order confirmation pdf without marking it as sent
+func (c *Client) PreviewOrderPDF(ctx context.Context, id int) (*Document, error) {
+	return c.getDocumentStream(ctx, fmt.Sprintf("orders/%d/preview", id), nil, fmt.Sprintf("order-%d.pdf", id), false)
+}
+
+// OrderIterator walks through every page of orders
+type OrderIterator struct {
+	it *pageIterator
+}
+
+// Orders iterates over all orders matching the params, fetching pages as needed
+func (c *Client) Orders(ctx context.Context, p *OrderQueryParams, opts ...IteratorOption) *OrderIterator {
+	params := OrderQueryParams{}
+	if p != nil {
+		params = *p
+	}
+	return &OrderIterator{it: newPageIterator(ctx, params.Page, func(ctx context.Context, page int) ([]interface{}, *MetaInformation, error) {
+		pageParams := params
+		pageParams.Page = page
+		resp, err := c.ListOrders(ctx, &pageParams)
+		if err != nil {
+			return nil, nil, err
+		}
+		items := make([]interface{}, len(resp.Orders))
+		for i, o := range resp.Orders {
+			items[i] = o
+		}
+		return items, resp.MetaInformation, nil
+	}, opts)}
+}
+
+// Next advances to the next order, returning false when done or on error
+func (i *OrderIterator) Next() bool {
+	return i.it.next()
+}
+
+// Order is the current order
+func (i *OrderIterator) Order() *OrderShort {
+	o, _ := i.it.cur.(*OrderShort)
+	return o
+}
+
+// Err is the error which stopped iteration, if any
+func (i *OrderIterator) Err() error {
+	return i.it.err
+}
+
+// Close stops iteration early
+func (i *OrderIterator) Close() {
+	i.it.close()
+}
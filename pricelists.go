@@ -0,0 +1,124 @@
+package fortnox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// PriceList data type
+type PriceList struct {
+	URL         string `json:"@url"`
+	Code        string `json:"Code"`
+	Comments    string `json:"Comments"`
+	Description string `json:"Description"`
+	PreSelected bool   `json:"PreSelected"`
+}
+
+// CreatePriceList payload for creating price lists
+type CreatePriceList struct {
+	Code        *string `json:"Code,omitempty"`
+	Comments    *string `json:"Comments,omitempty"`
+	Description *string `json:"Description,omitempty"`
+	PreSelected *bool   `json:"PreSelected,omitempty"`
+}
+
+// UpdatePriceList payload for updating price lists
+type UpdatePriceList CreatePriceList
+
+// PriceListQueryParams when searching price lists
+type PriceListQueryParams struct {
+	Page   int
+	Limit  int
+	Offset int
+	Extra  map[string][]string
+}
+
+func (p PriceListQueryParams) toValues() url.Values {
+
+	ret := make(url.Values)
+
+	if p.Limit > 0 {
+		ret["limit"] = []string{fmt.Sprintf("%d", p.Limit)}
+	}
+	if p.Offset > 0 {
+		ret["offset"] = []string{fmt.Sprintf("%d", p.Offset)}
+	}
+	if p.Page > 0 {
+		ret["page"] = []string{fmt.Sprintf("%d", p.Page)}
+	}
+	for k, vs := range p.Extra {
+		ret[k] = vs
+	}
+	return ret
+}
+
+// ListPriceListsResp Response when listing price lists
+type ListPriceListsResp struct {
+	PriceLists      []*PriceList     `json:"PriceLists"`
+	MetaInformation *MetaInformation `json:"MetaInformation"`
+}
+
+// ListPriceLists lists price lists
+func (c *Client) ListPriceLists(ctx context.Context, p *PriceListQueryParams) (*ListPriceListsResp, error) {
+	resp := &ListPriceListsResp{}
+
+	var vals url.Values
+	if p != nil {
+		vals = p.toValues()
+	}
+
+	err := c.request(ctx, "GET", "pricelists", nil, vals, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PriceListResp Response for single price list
+type PriceListResp struct {
+	PriceList PriceList `json:"PriceList"`
+}
+
+// GetPriceList gets one price list by code
+func (c *Client) GetPriceList(ctx context.Context, code string) (*PriceList, error) {
+
+	resp := &PriceListResp{}
+
+	err := c.request(ctx, "GET", "pricelists/"+url.PathEscape(code), nil, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.PriceList, nil
+}
+
+// CreatePriceList creates a price list
+func (c *Client) CreatePriceList(ctx context.Context, priceList *CreatePriceList) (*PriceList, error) {
+	resp := &PriceListResp{}
+	err := c.request(ctx, "POST", "pricelists/", &struct {
+		PriceList *CreatePriceList `json:"PriceList"`
+	}{
+		PriceList: priceList,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.PriceList, nil
+}
+
+// UpdatePriceList updates a price list. Fortnox doesn't allow deleting price lists
+func (c *Client) UpdatePriceList(ctx context.Context, code string, priceList *UpdatePriceList) (*PriceList, error) {
+	resp := &PriceListResp{}
+	err := c.request(ctx, "PUT", "pricelists/"+url.PathEscape(code), &struct {
+		PriceList *UpdatePriceList `json:"PriceList"`
+	}{
+		PriceList: priceList,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.PriceList, nil
+}
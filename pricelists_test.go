@@ -0,0 +1,52 @@
+package fortnox
+
+import (
+	"context"
+	"testing"
+)
+
+func TestClient_PriceLists(t *testing.T) {
+	api := newStubAPI(t, map[string]string{
+		"GET pricelists":          `{"PriceLists":[{"Code":"A","Description":"Standard","PreSelected":true}],"MetaInformation":{"@CurrentPage":1,"@TotalPages":1,"@TotalResources":1}}`,
+		"POST pricelists/":        `{"PriceList":{"Code":"B","Description":"Återförsäljare"}}`,
+		"GET prices/sublist/A/10": `{"Prices":[{"ArticleNumber":"10","PriceList":"A","FromQuantity":"0","Price":"100.00"},{"ArticleNumber":"10","PriceList":"A","FromQuantity":"10","Price":"90.00"}],"MetaInformation":{"@CurrentPage":1,"@TotalPages":1,"@TotalResources":2}}`,
+		"GET prices/A/10/2.5":     `{"Price":{"ArticleNumber":"10","PriceList":"A","FromQuantity":"2.5","Price":"95.00"}}`,
+		"PUT prices/A/10/10":      `{"Price":{"ArticleNumber":"10","PriceList":"A","FromQuantity":"10","Price":"85.00"}}`,
+		"DELETE prices/A/10/10":   ``,
+	})
+	defer api.Close()
+	c := api.client()
+	ctx := context.Background()
+
+	lists, err := c.ListPriceLists(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lists.PriceLists) != 1 || !lists.PriceLists[0].PreSelected {
+		t.Fatalf("unexpected price lists %+v", lists.PriceLists)
+	}
+	code, desc := "B", "Återförsäljare"
+	if pl, err := c.CreatePriceList(ctx, &CreatePriceList{Code: &code, Description: &desc}); err != nil || pl.Code != "B" {
+		t.Fatal("unexpected price list", pl, err)
+	}
+
+	prices, err := c.ListArticlePrices(ctx, "A", "10", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prices.Prices) != 2 || prices.Prices[1].FromQuantity != 10 || prices.Prices[1].Price != 90 {
+		t.Fatalf("unexpected prices %+v", prices.Prices)
+	}
+
+	// fractional quantities are kept in the path as is
+	if p, err := c.GetPrice(ctx, "A", "10", 2.5); err != nil || p.Price != 95 {
+		t.Fatal("unexpected price", p, err)
+	}
+	price := 85.0
+	if p, err := c.UpdatePrice(ctx, "A", "10", 10, &UpdatePrice{Price: &price}); err != nil || p.Price != 85 {
+		t.Fatal("unexpected price", p, err)
+	}
+	if err := c.DeletePrice(ctx, "A", "10", 10); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,151 @@
+package fortnox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Price data type. An article can have several prices in a list, one per quantity break.
+// In percentage based lists Percent is set instead of Price, relative to the article's price in the preselected list
+type Price struct {
+	URL           string   `json:"@url"`
+	ArticleNumber string   `json:"ArticleNumber"`
+	Date          Date     `json:"Date"`
+	FromQuantity  Floatish `json:"FromQuantity"`
+	Percent       Floatish `json:"Percent"`
+	Price         Floatish `json:"Price"`
+	PriceList     string   `json:"PriceList"`
+}
+
+// CreatePrice payload for creating prices
+type CreatePrice struct {
+	ArticleNumber *string  `json:"ArticleNumber,omitempty"`
+	FromQuantity  *float64 `json:"FromQuantity,omitempty"`
+	Percent       *float64 `json:"Percent,omitempty"`
+	Price         *float64 `json:"Price,omitempty"`
+	PriceList     *string  `json:"PriceList,omitempty"`
+}
+
+// UpdatePrice payload for updating prices
+type UpdatePrice struct {
+	FromQuantity *float64 `json:"FromQuantity,omitempty"`
+	Percent      *float64 `json:"Percent,omitempty"`
+	Price        *float64 `json:"Price,omitempty"`
+}
+
+// PriceQueryParams when listing prices
+type PriceQueryParams struct {
+	Page   int
+	Limit  int
+	Offset int
+	Extra  map[string][]string
+}
+
+func (p PriceQueryParams) toValues() url.Values {
+
+	ret := make(url.Values)
+
+	if p.Limit > 0 {
+		ret["limit"] = []string{fmt.Sprintf("%d", p.Limit)}
+	}
+	if p.Offset > 0 {
+		ret["offset"] = []string{fmt.Sprintf("%d", p.Offset)}
+	}
+	if p.Page > 0 {
+		ret["page"] = []string{fmt.Sprintf("%d", p.Page)}
+	}
+	for k, vs := range p.Extra {
+		ret[k] = vs
+	}
+	return ret
+}
+
+// ListPricesResp Response when listing prices
+type ListPricesResp struct {
+	Prices          []*Price         `json:"Prices"`
+	MetaInformation *MetaInformation `json:"MetaInformation"`
+}
+
+// ListPrices lists the prices in a price list
+func (c *Client) ListPrices(ctx context.Context, priceList string, p *PriceQueryParams) (*ListPricesResp, error) {
+	return c.listPrices(ctx, "prices/sublist/"+url.PathEscape(priceList), p)
+}
+
+// ListArticlePrices lists the quantity break prices of an article in a price list
+func (c *Client) ListArticlePrices(ctx context.Context, priceList string, articleNum string, p *PriceQueryParams) (*ListPricesResp, error) {
+	return c.listPrices(ctx, "prices/sublist/"+url.PathEscape(priceList)+"/"+url.PathEscape(articleNum), p)
+}
+
+func (c *Client) listPrices(ctx context.Context, resource string, p *PriceQueryParams) (*ListPricesResp, error) {
+	resp := &ListPricesResp{}
+
+	var vals url.Values
+	if p != nil {
+		vals = p.toValues()
+	}
+
+	err := c.request(ctx, "GET", resource, nil, vals, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PriceResp Response for single price
+type PriceResp struct {
+	Price Price `json:"Price"`
+}
+
+func priceResource(priceList string, articleNum string, fromQuantity float64) string {
+	return fmt.Sprintf("prices/%s/%s/%s", url.PathEscape(priceList), url.PathEscape(articleNum), strconv.FormatFloat(fromQuantity, 'f', -1, 64))
+}
+
+// GetPrice gets the price of an article in a price list from a quantity, 0 for the base price
+func (c *Client) GetPrice(ctx context.Context, priceList string, articleNum string, fromQuantity float64) (*Price, error) {
+
+	resp := &PriceResp{}
+
+	err := c.request(ctx, "GET", priceResource(priceList, articleNum, fromQuantity), nil, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Price, nil
+}
+
+// CreatePrice creates a price
+func (c *Client) CreatePrice(ctx context.Context, price *CreatePrice) (*Price, error) {
+	resp := &PriceResp{}
+	err := c.request(ctx, "POST", "prices/", &struct {
+		Price *CreatePrice `json:"Price"`
+	}{
+		Price: price,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Price, nil
+}
+
+// UpdatePrice updates a price
+func (c *Client) UpdatePrice(ctx context.Context, priceList string, articleNum string, fromQuantity float64, price *UpdatePrice) (*Price, error) {
+	resp := &PriceResp{}
+	err := c.request(ctx, "PUT", priceResource(priceList, articleNum, fromQuantity), &struct {
+		Price *UpdatePrice `json:"Price"`
+	}{
+		Price: price,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Price, nil
+}
+
+// DeletePrice deletes a price. The base price, from quantity 0, can't be deleted
+func (c *Client) DeletePrice(ctx context.Context, priceList string, articleNum string, fromQuantity float64) error {
+	return c.deleteResource(ctx, priceResource(priceList, articleNum, fromQuantity))
+}
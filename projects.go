@@ -0,0 +1,149 @@
+package fortnox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Project statuses
+const (
+	ProjectStatusNotStarted = "NOTSTARTED"
+	ProjectStatusOngoing    = "ONGOING"
+	ProjectStatusCompleted  = "COMPLETED"
+)
+
+// Project data type
+type Project struct {
+	URL           string `json:"@url"`
+	Comments      string `json:"Comments"`
+	ContactPerson string `json:"ContactPerson"`
+	Description   string `json:"Description"`
+	EndDate       Date   `json:"EndDate"`
+	ProjectLeader string `json:"ProjectLeader"`
+	ProjectNumber string `json:"ProjectNumber"`
+	StartDate     Date   `json:"StartDate"`
+	Status        string `json:"Status"`
+}
+
+// CreateProject payload for creating projects
+type CreateProject struct {
+	Comments      *string `json:"Comments,omitempty"`
+	ContactPerson *string `json:"ContactPerson,omitempty"`
+	Description   *string `json:"Description,omitempty"`
+	EndDate       *string `json:"EndDate,omitempty"`
+	ProjectLeader *string `json:"ProjectLeader,omitempty"`
+	ProjectNumber *string `json:"ProjectNumber,omitempty"`
+	StartDate     *string `json:"StartDate,omitempty"`
+	Status        *string `json:"Status,omitempty"`
+}
+
+// UpdateProject payload for updating projects
+type UpdateProject CreateProject
+
+// ProjectQueryParams when searching projects
+type ProjectQueryParams struct {
+	LastModified time.Time
+	Page         int
+	Limit        int
+	Offset       int
+	Extra        map[string][]string
+}
+
+func (p ProjectQueryParams) toValues() url.Values {
+
+	ret := make(url.Values)
+
+	if !p.LastModified.IsZero() {
+		ret["lastmodified"] = []string{p.LastModified.Format(TimeFormat)}
+	}
+	if p.Limit > 0 {
+		ret["limit"] = []string{fmt.Sprintf("%d", p.Limit)}
+	}
+	if p.Offset > 0 {
+		ret["offset"] = []string{fmt.Sprintf("%d", p.Offset)}
+	}
+	if p.Page > 0 {
+		ret["page"] = []string{fmt.Sprintf("%d", p.Page)}
+	}
+	for k, vs := range p.Extra {
+		ret[k] = vs
+	}
+	return ret
+}
+
+// ListProjectsResp Response when listing projects
+type ListProjectsResp struct {
+	Projects        []*Project       `json:"Projects"`
+	MetaInformation *MetaInformation `json:"MetaInformation"`
+}
+
+// ListProjects lists projects
+func (c *Client) ListProjects(ctx context.Context, p *ProjectQueryParams) (*ListProjectsResp, error) {
+	resp := &ListProjectsResp{}
+
+	var vals url.Values
+	if p != nil {
+		vals = p.toValues()
+	}
+
+	err := c.request(ctx, "GET", "projects", nil, vals, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ProjectResp Response for single project
+type ProjectResp struct {
+	Project Project `json:"Project"`
+}
+
+// GetProject gets one project by number
+func (c *Client) GetProject(ctx context.Context, projectNum string) (*Project, error) {
+
+	resp := &ProjectResp{}
+
+	err := c.request(ctx, "GET", "projects/"+url.PathEscape(projectNum), nil, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Project, nil
+}
+
+// CreateProject creates a project
+func (c *Client) CreateProject(ctx context.Context, project *CreateProject) (*Project, error) {
+	resp := &ProjectResp{}
+	err := c.request(ctx, "POST", "projects/", &struct {
+		Project *CreateProject `json:"Project"`
+	}{
+		Project: project,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Project, nil
+}
+
+// UpdateProject updates a project
+func (c *Client) UpdateProject(ctx context.Context, projectNum string, project *UpdateProject) (*Project, error) {
+	resp := &ProjectResp{}
+	err := c.request(ctx, "PUT", "projects/"+url.PathEscape(projectNum), &struct {
+		Project *UpdateProject `json:"Project"`
+	}{
+		Project: project,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Project, nil
+}
+
+// DeleteProject deletes one project
+func (c *Client) DeleteProject(ctx context.Context, projectNum string) error {
+	return c.deleteResource(ctx, "projects/"+url.PathEscape(projectNum))
+}
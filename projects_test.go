@@ -0,0 +1,41 @@
+package fortnox
+
+import (
+	"context"
+	"testing"
+)
+
+func TestClient_Projects(t *testing.T) {
+	api := newStubAPI(t, map[string]string{
+		"GET projects":       `{"Projects":[{"ProjectNumber":"P1","Description":"Bygget","Status":"ONGOING","StartDate":"2020-01-15"}],"MetaInformation":{"@CurrentPage":1,"@TotalPages":1,"@TotalResources":1}}`,
+		"POST projects/":     `{"Project":{"ProjectNumber":"P1","Description":"Bygget","Status":"NOTSTARTED"}}`,
+		"PUT projects/P1":    `{"Project":{"ProjectNumber":"P1","Description":"Bygget","Status":"COMPLETED"}}`,
+		"DELETE projects/P1": ``,
+	})
+	defer api.Close()
+	c := api.client()
+	ctx := context.Background()
+
+	list, err := c.ListProjects(ctx, &ProjectQueryParams{Limit: 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Projects) != 1 || list.Projects[0].Status != ProjectStatusOngoing || list.Projects[0].StartDate.String() != "2020-01-15" {
+		t.Fatalf("unexpected projects %+v", list.Projects)
+	}
+
+	desc, status := "Bygget", ProjectStatusCompleted
+	p, err := c.CreateProject(ctx, &CreateProject{Description: &desc})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, err = c.UpdateProject(ctx, p.ProjectNumber, &UpdateProject{Status: &status}); err != nil || p.Status != status {
+		t.Fatal("unexpected project", p, err)
+	}
+	if body := api.received("PUT projects/P1")[0].Body; body != `{"Project":{"Status":"COMPLETED"}}`+"\n" {
+		t.Fatal("unexpected body", body)
+	}
+	if err := c.DeleteProject(ctx, "P1"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,218 @@
+package fortnox
+
+import (
+	"context"
+	"crypto/sha256"
+	"reflect"
+	"sync"
+	"time"
+)
+
+const (
+	// FortnoxRateLimit is the documented max number of requests per FortnoxRatePeriod for each access token
+	FortnoxRateLimit = 25
+	// FortnoxRatePeriod is the window FortnoxRateLimit applies to
+	FortnoxRatePeriod = 5 * time.Second
+
+	// a burst of 5 plus 20 refilled over the period keeps every window at or below FortnoxRateLimit
+	defaultRateBurst = 5
+	defaultRateLimit = FortnoxRateLimit - defaultRateBurst
+)
+
+var (
+	sharedLimitersMu sync.Mutex
+	sharedLimiters   = map[limiterKey]*sharedLimiter{}
+)
+
+// limiterKey identifies a tenant's limiter. Tokens are hashed so credentials aren't kept around in the map
+type limiterKey struct {
+	tenant interface{}
+	opts   RateLimitOpts
+}
+
+// sharedLimiter is dropped when the last client using it is garbage collected
+type sharedLimiter struct {
+	limiter *RateLimiter
+	clients int
+}
+
+// clock allows tests to control time
+type clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// RateLimitOpts configures the token bucket used to throttle requests
+type RateLimitOpts struct {
+	// Limit is the number of requests refilled per Per. Zero or less disables rate limiting
+	Limit int
+	Per   time.Duration
+	// Burst is the max number of requests that can be sent without waiting
+	Burst int
+}
+
+// WithRateLimitOpts helper for changing the rate limit. Clients with the same access token and limits share a limiter
+func WithRateLimitOpts(limit int, per time.Duration, burst int) OptionsFunc {
+	return func(o *ClientOptions) {
+		o.RateLimit = &RateLimitOpts{Limit: limit, Per: per, Burst: burst}
+	}
+}
+
+// WithRateLimiter helper for using your own limiter, e.g. to share one between tenants
+func WithRateLimiter(l *RateLimiter) OptionsFunc {
+	return func(o *ClientOptions) {
+		o.RateLimiter = l
+	}
+}
+
+// RateLimiterStats are counters for how much a limiter has throttled
+type RateLimiterStats struct {
+	Requests int64
+	// Waits is the number of requests which had to wait
+	Waits     int64
+	TotalWait time.Duration
+	MaxWait   time.Duration
+}
+
+// RateLimiter is a token bucket, safe for concurrent use
+type RateLimiter struct {
+	mu       sync.Mutex
+	clock    clock
+	interval time.Duration
+	burst    float64
+	tokens   float64
+	last     time.Time
+	stats    RateLimiterStats
+}
+
+// NewRateLimiter creates a limiter allowing limit requests per period, with at most burst sent at once
+func NewRateLimiter(limit int, per time.Duration, burst int) *RateLimiter {
+	return newRateLimiter(limit, per, burst, realClock{})
+}
+
+func newRateLimiter(limit int, per time.Duration, burst int, c clock) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		clock:    c,
+		interval: per / time.Duration(limit),
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     c.Now(),
+	}
+}
+
+// Wait blocks until a request may be sent or ctx is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := l.clock.Now()
+	l.refill(now)
+
+	// reserve a token, going into debt if there are none left
+	l.tokens--
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens * float64(l.interval))
+	}
+
+	l.stats.Requests++
+	if wait > 0 {
+		l.stats.Waits++
+		l.stats.TotalWait += wait
+		if wait > l.stats.MaxWait {
+			l.stats.MaxWait = wait
+		}
+	}
+	l.mu.Unlock()
+
+	if wait == 0 {
+		return nil
+	}
+
+	select {
+	case <-l.clock.After(wait):
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Stats gets the limiter's counters
+func (l *RateLimiter) Stats() RateLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stats
+}
+
+func (l *RateLimiter) refill(now time.Time) {
+	elapsed := now.Sub(l.last)
+	if elapsed <= 0 {
+		return
+	}
+	l.last = now
+	l.tokens += float64(elapsed) / float64(l.interval)
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+}
+
+// RateLimiter gets the limiter used by the client, nil if rate limiting is disabled
+func (c *Client) RateLimiter() *RateLimiter {
+	return c.limiter
+}
+
+// rateLimiter gets the limiter for a new client, and if it's shared, a func to call once the client is gone
+func (o *ClientOptions) rateLimiter() (*RateLimiter, func()) {
+	if o.RateLimiter != nil {
+		return o.RateLimiter, nil
+	}
+
+	opts := RateLimitOpts{Limit: defaultRateLimit, Per: FortnoxRatePeriod, Burst: defaultRateBurst}
+	if o.RateLimit != nil {
+		opts = *o.RateLimit
+	}
+	if opts.Limit <= 0 || opts.Per <= 0 {
+		return nil, nil
+	}
+
+	// the quota is per tenant, so share limiters between clients with the same credentials
+	key := limiterKey{opts: opts}
+	switch {
+	case o.OAuth == nil:
+		key.tenant = sha256.Sum256([]byte("token:" + o.AccessToken))
+	case o.TokenStore != nil && reflect.TypeOf(o.TokenStore).Comparable():
+		// the key holds on to the store, so its address can't be reused by another store
+		key.tenant = o.TokenStore
+	case o.Token != nil:
+		// refresh tokens rotate, but clients created from the same one are for the same tenant
+		key.tenant = sha256.Sum256([]byte("oauth:" + o.Token.RefreshToken))
+	default:
+		return NewRateLimiter(opts.Limit, opts.Per, opts.Burst), nil
+	}
+
+	sharedLimitersMu.Lock()
+	defer sharedLimitersMu.Unlock()
+	shared, ok := sharedLimiters[key]
+	if !ok {
+		shared = &sharedLimiter{limiter: NewRateLimiter(opts.Limit, opts.Per, opts.Burst)}
+		sharedLimiters[key] = shared
+	}
+	shared.clients++
+
+	return shared.limiter, func() {
+		sharedLimitersMu.Lock()
+		defer sharedLimitersMu.Unlock()
+		if shared.clients--; shared.clients == 0 {
+			delete(sharedLimiters, key)
+		}
+	}
+}
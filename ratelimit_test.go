@@ -0,0 +1,183 @@
+package fortnox
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	c.waiters = append(c.waiters, fakeWaiter{at: c.now.Add(d), ch: ch})
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	remaining := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.at.After(c.now) {
+			remaining = append(remaining, w)
+			continue
+		}
+		w.ch <- c.now
+	}
+	c.waiters = remaining
+}
+
+func (c *fakeClock) Waiting() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.waiters)
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	clk := newFakeClock()
+	l := newRateLimiter(20, 5*time.Second, 5, clk)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if s := l.Stats(); s.Waits != 0 {
+		t.Fatal("burst should not wait, waited", s.Waits)
+	}
+
+	done := make(chan error)
+	go func() { done <- l.Wait(ctx) }()
+	for clk.Waiting() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	clk.Advance(200 * time.Millisecond)
+	select {
+	case <-done:
+		t.Fatal("should still be waiting")
+	default:
+	}
+
+	clk.Advance(50 * time.Millisecond)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	s := l.Stats()
+	if s.Requests != 6 || s.Waits != 1 {
+		t.Fatalf("unexpected stats %+v", s)
+	}
+	if s.MaxWait != 250*time.Millisecond {
+		t.Fatal("unexpected max wait", s.MaxWait)
+	}
+}
+
+func TestRateLimiter_WaitCancelled(t *testing.T) {
+	clk := newFakeClock()
+	l := newRateLimiter(1, time.Second, 1, clk)
+
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.Wait(ctx); err != context.Canceled {
+		t.Fatal("expected cancel, got", err)
+	}
+
+	// cancelled reservation is returned to the bucket
+	clk.Advance(time.Second)
+	done := make(chan error, 1)
+	go func() { done <- l.Wait(context.Background()) }()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNewClient_SharesRateLimiter(t *testing.T) {
+	c1 := NewClient(WithAuthOpts("shared", "secret"))
+	c2 := NewClient(WithAuthOpts("shared", "secret"))
+	c3 := NewClient(WithAuthOpts("other", "secret"))
+
+	if c1.RateLimiter() == nil || c1.RateLimiter() != c2.RateLimiter() {
+		t.Fatal("expected clients with same token to share limiter")
+	}
+	if c1.RateLimiter() == c3.RateLimiter() {
+		t.Fatal("expected clients with different tokens to have different limiters")
+	}
+	if NewClient(WithRateLimitOpts(0, 0, 0)).RateLimiter() != nil {
+		t.Fatal("expected rate limiting to be disabled")
+	}
+}
+
+func TestNewClient_DropsUnusedSharedLimiter(t *testing.T) {
+	shared := func(l *RateLimiter) bool {
+		sharedLimitersMu.Lock()
+		defer sharedLimitersMu.Unlock()
+		for _, s := range sharedLimiters {
+			if s.limiter == l {
+				return true
+			}
+		}
+		return false
+	}
+
+	var l *RateLimiter
+	func() {
+		c := NewClient(WithAuthOpts("short-lived", "secret"))
+		l = c.RateLimiter()
+		if !shared(l) {
+			t.Fatal("expected the limiter to be shared")
+		}
+	}()
+
+	for i := 0; i < 100 && shared(l); i++ {
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+	if shared(l) {
+		t.Fatal("expected the limiter to be dropped with its last client")
+	}
+}
+
+func TestNewClient_SharesOAuthRateLimiter(t *testing.T) {
+	config := &OAuthConfig{ClientID: "id", ClientSecret: "secret"}
+	c1 := NewClient(WithOAuthOpts(config, &Token{AccessToken: "a", RefreshToken: "r"}))
+	c2 := NewClient(WithOAuthOpts(config, &Token{AccessToken: "a", RefreshToken: "r"}))
+	c3 := NewClient(WithOAuthOpts(config, &Token{AccessToken: "b", RefreshToken: "other"}))
+
+	if c1.RateLimiter() != c2.RateLimiter() {
+		t.Fatal("expected clients with the same token to share limiter")
+	}
+	if c1.RateLimiter() == c3.RateLimiter() {
+		t.Fatal("expected clients with different tokens to have different limiters")
+	}
+}
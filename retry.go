@@ -0,0 +1,128 @@
+package fortnox
+
+import (
+	"context"
+	"errors"
+	pkgerrors "github.com/pkg/errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultRetryPolicy is used by clients unless told otherwise
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	MinBackoff:  500 * time.Millisecond,
+	MaxBackoff:  30 * time.Second,
+}
+
+// RetryPolicy decides how requests failing with 429 or 5xx are retried.
+// GET, PUT and DELETE are retried, POST only if RetryPOST is set.
+type RetryPolicy struct {
+	// MaxAttempts including the first one, 1 or less disables retries
+	MaxAttempts int
+	// MinBackoff is the wait before the first retry, doubled for each one after
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// RetryPOST allows retrying rate limited POSTs. These are safe since fortnox rejects them before doing anything,
+	// whereas after a 5xx the resource may have been created anyway, so those are never retried.
+	RetryPOST bool
+}
+
+// WithRetryOpts helper for changing the retry policy
+func WithRetryOpts(policy RetryPolicy) OptionsFunc {
+	return func(o *ClientOptions) {
+		o.Retry = &policy
+	}
+}
+
+// retryPolicy returns a copy, so changes to DefaultRetryPolicy don't affect requests in flight
+func (o *ClientOptions) retryPolicy() RetryPolicy {
+	if o.Retry == nil {
+		return DefaultRetryPolicy
+	}
+	return *o.Retry
+}
+
+// backoff returns how long to wait before retrying, if the request should be retried at all.
+// Actions, such as creating an invoice from an order, aren't idempotent whatever their method, so they're only retried
+// when fortnox can't have acted on them: after a 429 or when the connection couldn't be made.
+func (p *RetryPolicy) backoff(method string, action bool, attempt int, err error) (time.Duration, bool) {
+	if attempt >= p.MaxAttempts {
+		return 0, false
+	}
+
+	method = strings.ToUpper(method)
+	idempotent := !action && (method == "GET" || method == "PUT" || method == "DELETE" || method == "HEAD")
+
+	fErr, ok := err.(FnoxError)
+	switch {
+	case !ok:
+		if !notSent(err) {
+			return 0, false
+		}
+	case fErr.HTTPStatus == http.StatusTooManyRequests:
+		if !idempotent && !action && !(method == "POST" && p.RetryPOST) {
+			return 0, false
+		}
+	case fErr.HTTPStatus >= 500:
+		if !idempotent {
+			return 0, false
+		}
+	default:
+		return 0, false
+	}
+
+	if fErr.RetryAfter > 0 {
+		if p.MaxBackoff > 0 && fErr.RetryAfter > p.MaxBackoff {
+			return p.MaxBackoff, true
+		}
+		return fErr.RetryAfter, true
+	}
+
+	wait := p.MinBackoff << uint(attempt-1)
+	if wait <= 0 || (p.MaxBackoff > 0 && wait > p.MaxBackoff) {
+		wait = p.MaxBackoff
+	}
+	// jitter so clients sharing a quota don't retry in lockstep
+	if wait > 1 {
+		wait = wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+	}
+	return wait, true
+}
+
+// notSent reports whether the request failed before anything was sent, because the connection couldn't be made
+func notSent(err error) bool {
+	var opErr *net.OpError
+	return errors.As(pkgerrors.Cause(err), &opErr) && opErr.Op == "dial"
+}
+
+// parseRetryAfter parses the header as either seconds or a http date
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(timeNow()); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+func sleepCtx(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
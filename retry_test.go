@@ -0,0 +1,217 @@
+package fortnox
+
+import (
+	"context"
+	"fmt"
+	"github.com/byrnedo/go-fortnox/fortnoxtest"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newFlakyServer(failures int32, status int, calls *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(calls, 1) <= failures {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(status)
+			fmt.Fprint(w, `{"ErrorInformation":{"Error":1,"Message":"Try again","Code":0}}`)
+			return
+		}
+		fmt.Fprint(w, `{"Label":{"Id":1,"Description":"test"}}`)
+	}))
+}
+
+func newRetryTestClient(url string, policy RetryPolicy) *Client {
+	return NewClient(WithAuthOpts("retry", "secret"), WithURLOpts(url+"/"), WithRateLimitOpts(0, 0, 0), WithRetryOpts(policy))
+}
+
+func TestClient_RetriesIdempotent(t *testing.T) {
+	var calls int32
+	ts := newFlakyServer(2, http.StatusServiceUnavailable, &calls)
+	defer ts.Close()
+
+	c := newRetryTestClient(ts.URL, RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+	if _, err := c.UpdateLabel(context.Background(), 1, "test"); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 3 {
+		t.Fatal("expected 3 calls, got", calls)
+	}
+}
+
+func TestClient_GivesUpAfterMaxAttempts(t *testing.T) {
+	var calls int32
+	ts := newFlakyServer(5, http.StatusInternalServerError, &calls)
+	defer ts.Close()
+
+	c := newRetryTestClient(ts.URL, RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond})
+	_, err := c.UpdateLabel(context.Background(), 1, "test")
+	if fErr, ok := err.(FnoxError); !ok || fErr.HTTPStatus != 500 {
+		t.Fatal("expected 500, got", err)
+	}
+	if calls != 2 {
+		t.Fatal("expected 2 calls, got", calls)
+	}
+}
+
+func TestClient_RetriesPOST(t *testing.T) {
+	var calls int32
+	ts := newFlakyServer(1, http.StatusInternalServerError, &calls)
+	defer ts.Close()
+
+	// never after a server error
+	c := newRetryTestClient(ts.URL, RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, RetryPOST: true})
+	if _, err := c.CreateLabel(context.Background(), "test"); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != 1 {
+		t.Fatal("expected 1 call, got", calls)
+	}
+
+	calls = 0
+	ts429 := newFlakyServer(1, http.StatusTooManyRequests, &calls)
+	defer ts429.Close()
+
+	c = newRetryTestClient(ts429.URL, RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond})
+	if _, err := c.CreateLabel(context.Background(), "test"); err == nil {
+		t.Fatal("expected error")
+	}
+
+	calls = 0
+	c = newRetryTestClient(ts429.URL, RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, RetryPOST: true})
+	if _, err := c.CreateLabel(context.Background(), "test"); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Fatal("expected 2 calls, got", calls)
+	}
+}
+
+func TestClient_DoesNotRetryActions(t *testing.T) {
+	srv := fortnoxtest.NewServer()
+	defer srv.Close()
+	if _, err := srv.Add(fortnoxtest.Customers, map[string]interface{}{"CustomerNumber": "1", "Name": "Kund"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.Add(fortnoxtest.Orders, map[string]interface{}{"CustomerNumber": "1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	countRequests := func() int { return countFakeRequests(srv, "PUT", "orders/1/createinvoice") }
+	c := newRetryTestClient(strings.TrimSuffix(srv.BaseURL(), "/"), RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+
+	// the invoice may have been created before the server failed
+	srv.InjectFault(fortnoxtest.Fault{Method: "PUT", Path: "orders/1/createinvoice", Status: http.StatusInternalServerError, Times: 1})
+	if _, err := c.CreateInvoiceFromOrder(context.Background(), 1); !IsServerError(err) {
+		t.Fatal("expected server error, got", err)
+	}
+	if n := countRequests(); n != 1 {
+		t.Fatal("expected 1 request, got", n)
+	}
+
+	// rate limited requests weren't acted on
+	srv.InjectFault(fortnoxtest.Fault{Method: "PUT", Path: "orders/1/createinvoice", Status: http.StatusTooManyRequests, Times: 1})
+	if _, err := c.CreateInvoiceFromOrder(context.Background(), 1); err != nil {
+		t.Fatal(err)
+	}
+	if n := countRequests(); n != 3 {
+		t.Fatal("expected 3 requests, got", n)
+	}
+}
+
+func TestClient_DoesNotRetryInvoiceActions(t *testing.T) {
+	srv := fortnoxtest.NewServer()
+	defer srv.Close()
+	if _, err := srv.Add(fortnoxtest.Customers, map[string]interface{}{"CustomerNumber": "1", "Name": "Kund"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.Add(fortnoxtest.Invoices, map[string]interface{}{"CustomerNumber": "1"}); err != nil {
+		t.Fatal(err)
+	}
+	c := newRetryTestClient(strings.TrimSuffix(srv.BaseURL(), "/"), RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+	srv.InjectFault(fortnoxtest.Fault{Path: "invoices/1/", Status: http.StatusBadGateway})
+
+	if _, err := c.BookkeepInvoice(context.Background(), 1); !IsServerError(err) {
+		t.Fatal("expected server error, got", err)
+	}
+	if n := countFakeRequests(srv, "PUT", "invoices/1/bookkeep"); n != 1 {
+		t.Fatal("expected 1 bookkeep request, got", n)
+	}
+	if _, err := c.PrintInvoicePDF(context.Background(), 1); !IsServerError(err) {
+		t.Fatal("expected server error, got", err)
+	}
+	if n := countFakeRequests(srv, "GET", "invoices/1/print"); n != 1 {
+		t.Fatal("expected 1 print request, got", n)
+	}
+	if _, err := c.PreviewInvoicePDF(context.Background(), 1); !IsServerError(err) {
+		t.Fatal("expected server error, got", err)
+	}
+	if n := countFakeRequests(srv, "GET", "invoices/1/preview"); n != 3 {
+		t.Fatal("expected preview to be retried, got requests:", n)
+	}
+}
+
+func countFakeRequests(srv *fortnoxtest.Server, method, path string) int {
+	n := 0
+	for _, r := range srv.Requests() {
+		if r.Method == method && r.Path == path {
+			n++
+		}
+	}
+	return n
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Second}
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: fmt.Errorf("connection refused")}
+	readErr := &net.OpError{Op: "read", Net: "tcp", Err: fmt.Errorf("connection reset")}
+
+	for _, tc := range []struct {
+		name   string
+		method string
+		action bool
+		err    error
+		retry  bool
+	}{
+		{"get server error", "GET", false, FnoxError{HTTPStatus: 502}, true},
+		{"action server error", "PUT", true, FnoxError{HTTPStatus: 502}, false},
+		{"action rate limited", "PUT", true, FnoxError{HTTPStatus: 429}, true},
+		{"post rate limited", "POST", false, FnoxError{HTTPStatus: 429}, false},
+		{"action not connected", "POST", true, dialErr, true},
+		{"action connection lost", "GET", true, readErr, false},
+		{"bad request", "GET", false, FnoxError{HTTPStatus: 400}, false},
+	} {
+		if _, ok := p.backoff(tc.method, tc.action, 1, tc.err); ok != tc.retry {
+			t.Errorf("%s: expected retry %v", tc.name, tc.retry)
+		}
+	}
+
+	if wait, ok := p.backoff("GET", false, 1, FnoxError{HTTPStatus: 429, RetryAfter: time.Hour}); !ok || wait != time.Second {
+		t.Fatal("expected retry after to be capped at max backoff, got", wait)
+	}
+}
+
+func TestClientOptions_RetryPolicyIsCopy(t *testing.T) {
+	o := &ClientOptions{}
+	p := o.retryPolicy()
+	p.MaxAttempts = 100
+	if DefaultRetryPolicy.MaxAttempts == 100 {
+		t.Fatal("default retry policy was changed")
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if d := parseRetryAfter("3"); d != 3*time.Second {
+		t.Fatal("unexpected", d)
+	}
+	if d := parseRetryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)); d <= 0 || d > time.Minute {
+		t.Fatal("unexpected", d)
+	}
+	if d := parseRetryAfter("soon"); d != 0 {
+		t.Fatal("unexpected", d)
+	}
+}
@@ -0,0 +1,470 @@
+package fortnox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultWebSocketURL is fortnox's websocket api for topic events
+	DefaultWebSocketURL = "wss://ws.fortnox.se/topics-v1"
+	// DefaultPingInterval is how often a Subscriber pings fortnox to detect dead connections
+	DefaultPingInterval = 30 * time.Second
+
+	// maxTopicMessageSize bounds the size of a single message from fortnox
+	maxTopicMessageSize = 1 << 20
+)
+
+// Topics which can be subscribed to
+const (
+	TopicArticles  = "articles"
+	TopicCustomers = "customers"
+	TopicInvoices  = "invoices"
+	TopicOffers    = "offers"
+	TopicOrders    = "orders"
+	TopicSuppliers = "suppliers"
+)
+
+// Event actions, see TopicEvent.Action
+const (
+	EventCreated = "created"
+	EventUpdated = "updated"
+	EventDeleted = "deleted"
+)
+
+// TopicEvent is a change published by fortnox
+type TopicEvent struct {
+	Topic  string `json:"topic"`
+	Offset string `json:"offset"`
+	// Type is e.g. "invoice-updated-v1"
+	Type      string    `json:"type"`
+	TenantID  int64     `json:"tenantId"`
+	EntityID  StringIsh `json:"entityId"`
+	Year      Intish    `json:"year"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Action is what happened to the entity, e.g. EventCreated
+func (e TopicEvent) Action() string {
+	parts := strings.Split(e.Type, "-")
+	if n := len(parts); n > 1 && strings.HasPrefix(parts[n-1], "v") {
+		parts = parts[:n-1]
+	}
+	return parts[len(parts)-1]
+}
+
+// EventHandler handles topic events. Returning an error stops the subscriber without saving the event's offset,
+// so events are delivered at least once.
+type EventHandler func(ctx context.Context, ev TopicEvent) error
+
+// OffsetStore persists the offset of the last handled event per topic
+type OffsetStore interface {
+	// LoadOffset returns "" if the topic has never been subscribed to
+	LoadOffset(ctx context.Context, topic string) (string, error)
+	SaveOffset(ctx context.Context, topic string, offset string) error
+}
+
+// MemoryOffsetStore keeps offsets in memory
+type MemoryOffsetStore struct {
+	mu      sync.Mutex
+	offsets map[string]string
+}
+
+// NewMemoryOffsetStore creates an empty memory store
+func NewMemoryOffsetStore() *MemoryOffsetStore {
+	return &MemoryOffsetStore{offsets: map[string]string{}}
+}
+
+// LoadOffset loads an offset
+func (s *MemoryOffsetStore) LoadOffset(ctx context.Context, topic string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.offsets[topic], nil
+}
+
+// SaveOffset saves an offset
+func (s *MemoryOffsetStore) SaveOffset(ctx context.Context, topic string, offset string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.offsets[topic] = offset
+	return nil
+}
+
+// FileOffsetStore keeps the offsets of all topics in a json file
+type FileOffsetStore struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+// NewFileOffsetStore creates a file store
+func NewFileOffsetStore(path string) *FileOffsetStore {
+	return &FileOffsetStore{Path: path}
+}
+
+func (s *FileOffsetStore) load() (map[string]string, error) {
+	offsets := map[string]string{}
+	data, err := ioutil.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return offsets, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read offset file")
+	}
+	if err := json.Unmarshal(data, &offsets); err != nil {
+		return nil, errors.Wrap(err, "failed to decode offset file")
+	}
+	return offsets, nil
+}
+
+// LoadOffset loads an offset
+func (s *FileOffsetStore) LoadOffset(ctx context.Context, topic string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	offsets, err := s.load()
+	if err != nil {
+		return "", err
+	}
+	return offsets[topic], nil
+}
+
+// SaveOffset saves an offset, replacing the file atomically
+func (s *FileOffsetStore) SaveOffset(ctx context.Context, topic string, offset string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	offsets, err := s.load()
+	if err != nil {
+		return err
+	}
+	offsets[topic] = offset
+	data, err := json.Marshal(offsets)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(s.Path), filepath.Base(s.Path)+".tmp")
+	if err != nil {
+		return errors.Wrap(err, "failed to create offset file")
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return errors.Wrap(err, "failed to write offset file")
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return errors.Wrap(err, "failed to write offset file")
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+// TopicCommandError is returned when fortnox rejects a websocket command
+type TopicCommandError struct {
+	Command        string
+	Result         string
+	InvalidTenants []string
+	InvalidTopics  []string
+}
+
+// Error pretty print error
+func (e TopicCommandError) Error() string {
+	msg := fmt.Sprintf("%s: %s", e.Command, e.Result)
+	if len(e.InvalidTenants) > 0 {
+		msg += fmt.Sprintf(", %d invalid access tokens", len(e.InvalidTenants))
+	}
+	if len(e.InvalidTopics) > 0 {
+		msg += ", invalid topics " + strings.Join(e.InvalidTopics, ", ")
+	}
+	return msg
+}
+
+// Is matches ErrUnauthorized for rejected access tokens and ErrValidation for unknown topics
+func (e TopicCommandError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return len(e.InvalidTenants) > 0
+	case ErrValidation:
+		return len(e.InvalidTopics) > 0
+	}
+	return false
+}
+
+type topicMessage struct {
+	Type           string           `json:"type"`
+	Result         string           `json:"result"`
+	Response       string           `json:"response"`
+	InvalidTenants []string         `json:"invalidTenants"`
+	InvalidTopics  []string         `json:"invalidTopics"`
+	TenantIDs      map[string]int64 `json:"tenantIds"`
+}
+
+// Subscriber receives change events from fortnox's websocket api, reconnecting and resuming from the
+// stored offsets when the connection drops
+type Subscriber struct {
+	Client  *Client
+	Store   OffsetStore
+	Handler EventHandler
+	// URL defaults to DefaultWebSocketURL
+	URL string
+	// Dialer connects to URL, set its TLSClientConfig or Proxy to customize the connection.
+	// Defaults to websocket.DefaultDialer, which uses the proxy from the environment
+	Dialer *websocket.Dialer
+	// AccessTokens of the tenants to subscribe to, defaults to the client's own
+	AccessTokens []string
+	// PingInterval defaults to DefaultPingInterval, the connection is considered dead after two intervals without data
+	PingInterval time.Duration
+	// MinBackoff and MaxBackoff bound the wait between reconnects
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	mu      sync.Mutex
+	tenants map[string]int64
+}
+
+// NewSubscriber creates a subscriber
+func NewSubscriber(c *Client, store OffsetStore, handler EventHandler) *Subscriber {
+	return &Subscriber{
+		Client:       c,
+		Store:        store,
+		Handler:      handler,
+		URL:          DefaultWebSocketURL,
+		PingInterval: DefaultPingInterval,
+		MinBackoff:   time.Second,
+		MaxBackoff:   time.Minute,
+	}
+}
+
+// Tenants maps the subscribed access tokens to the tenant ids found in events, once connected
+func (s *Subscriber) Tenants() map[string]int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tenants := make(map[string]int64, len(s.tenants))
+	for k, v := range s.tenants {
+		tenants[k] = v
+	}
+	return tenants
+}
+
+// Run subscribes to the topics until ctx is done, the handler fails or fortnox rejects the subscription
+func (s *Subscriber) Run(ctx context.Context, topics ...string) error {
+	if len(topics) == 0 {
+		return errors.New("no topics to subscribe to")
+	}
+
+	attempt := 0
+	for {
+		subscribed, err := s.session(ctx, topics)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if perm, ok := err.(permanentError); ok {
+			return perm.err
+		}
+
+		if subscribed {
+			attempt = 0
+		}
+		attempt++
+		if err := sleepCtx(ctx, s.backoff(attempt)); err != nil {
+			return err
+		}
+	}
+}
+
+// permanentError stops Run instead of reconnecting
+type permanentError struct {
+	err error
+}
+
+func (p permanentError) Error() string {
+	return p.err.Error()
+}
+
+func (s *Subscriber) backoff(attempt int) time.Duration {
+	wait := s.MinBackoff << uint(attempt-1)
+	if wait <= 0 || (s.MaxBackoff > 0 && wait > s.MaxBackoff) {
+		wait = s.MaxBackoff
+	}
+	if wait > 1 {
+		wait = wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+	}
+	return wait
+}
+
+// session connects, subscribes and handles events until the connection fails
+func (s *Subscriber) session(ctx context.Context, topics []string) (subscribed bool, err error) {
+	secret, tokens, err := s.credentials(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	dialer := s.Dialer
+	if dialer == nil {
+		dialer = websocket.DefaultDialer
+	}
+	conn, _, err := dialer.DialContext(ctx, s.URL, nil)
+	if err != nil {
+		return false, errors.Wrap(err, "error connecting to websocket")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	ping := s.PingInterval
+	if ping <= 0 {
+		ping = DefaultPingInterval
+	}
+	conn.SetReadLimit(maxTopicMessageSize)
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * ping))
+	})
+
+	resp, err := s.command(conn, ping, map[string]interface{}{
+		"command":      "add-tenants-v1",
+		"clientSecret": secret,
+		"accessTokens": tokens,
+	})
+	if err != nil {
+		return false, err
+	}
+	s.mu.Lock()
+	s.tenants = resp.TenantIDs
+	s.mu.Unlock()
+
+	type topicOffset struct {
+		Topic  string `json:"topic"`
+		Offset string `json:"offset,omitempty"`
+	}
+	var offsets []topicOffset
+	for _, topic := range topics {
+		offset, err := s.Store.LoadOffset(ctx, topic)
+		if err != nil {
+			return false, permanentError{errors.Wrap(err, "failed to load offset")}
+		}
+		offsets = append(offsets, topicOffset{Topic: topic, Offset: offset})
+	}
+	if _, err := s.command(conn, ping, map[string]interface{}{
+		"command": "add-topics-v1",
+		"topics":  offsets,
+	}); err != nil {
+		return false, err
+	}
+
+	if _, err := s.command(conn, ping, map[string]interface{}{
+		"command": "subscribe-v1",
+	}); err != nil {
+		return false, err
+	}
+
+	go func() {
+		for {
+			if err := sleepCtx(ctx, ping); err != nil {
+				return
+			}
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(ping)); err != nil {
+				cancel()
+				return
+			}
+		}
+	}()
+
+	for {
+		msg, err := s.read(conn, ping)
+		if err != nil {
+			return true, err
+		}
+
+		ev := TopicEvent{}
+		if err := json.Unmarshal(msg, &ev); err != nil {
+			return true, errors.Wrap(err, "failed to decode event")
+		}
+		if ev.Topic == "" {
+			// stray command responses
+			continue
+		}
+
+		if err := s.Handler(ctx, ev); err != nil {
+			return true, permanentError{err}
+		}
+		if err := s.Store.SaveOffset(ctx, ev.Topic, ev.Offset); err != nil {
+			return true, permanentError{errors.Wrap(err, "failed to save offset")}
+		}
+	}
+}
+
+func (s *Subscriber) credentials(ctx context.Context) (secret string, tokens []string, err error) {
+	c := s.Client
+	secret = c.clientOptions.ClientSecret
+	if c.usesOAuth() {
+		secret = c.clientOptions.OAuth.ClientSecret
+	}
+
+	tokens = s.AccessTokens
+	if len(tokens) > 0 {
+		return secret, tokens, nil
+	}
+	if !c.usesOAuth() {
+		return secret, []string{c.clientOptions.AccessToken}, nil
+	}
+	token, err := c.validToken(ctx, nil)
+	if err != nil {
+		return "", nil, err
+	}
+	return secret, []string{token.AccessToken}, nil
+}
+
+func (s *Subscriber) read(conn *websocket.Conn, ping time.Duration) ([]byte, error) {
+	if err := conn.SetReadDeadline(time.Now().Add(2 * ping)); err != nil {
+		return nil, err
+	}
+	_, msg, err := conn.ReadMessage()
+	return msg, err
+}
+
+// command sends a command and waits for its response
+func (s *Subscriber) command(conn *websocket.Conn, ping time.Duration, cmd map[string]interface{}) (*topicMessage, error) {
+	data, err := json.Marshal(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+		return nil, err
+	}
+
+	name := cmd["command"].(string)
+	for {
+		msg, err := s.read(conn, ping)
+		if err != nil {
+			return nil, err
+		}
+		resp := &topicMessage{}
+		if err := json.Unmarshal(msg, resp); err != nil {
+			return nil, errors.Wrap(err, "failed to decode command response")
+		}
+		if resp.Type != "command-response" || resp.Response != name {
+			continue
+		}
+		if resp.Result != "ok" || len(resp.InvalidTenants) > 0 || len(resp.InvalidTopics) > 0 {
+			return nil, permanentError{TopicCommandError{
+				Command:        name,
+				Result:         resp.Result,
+				InvalidTenants: resp.InvalidTenants,
+				InvalidTopics:  resp.InvalidTopics,
+			}}
+		}
+		return resp, nil
+	}
+}
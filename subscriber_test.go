@@ -0,0 +1,237 @@
+package fortnox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/websocket"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// topicServer is a stand-in for fortnox's websocket api. Each connection is handled by serve
+type topicServer struct {
+	*httptest.Server
+	t     *testing.T
+	serve func(conn *websocket.Conn, n int)
+
+	mu    sync.Mutex
+	conns int
+}
+
+func newTopicServer(t *testing.T, serve func(conn *websocket.Conn, n int)) *topicServer {
+	s := newUnstartedTopicServer(t, serve)
+	s.Start()
+	return s
+}
+
+func newUnstartedTopicServer(t *testing.T, serve func(conn *websocket.Conn, n int)) *topicServer {
+	s := &topicServer{t: t, serve: serve}
+	upgrader := websocket.Upgrader{}
+	s.Server = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+
+		s.mu.Lock()
+		s.conns++
+		n := s.conns
+		s.mu.Unlock()
+		s.serve(conn, n)
+	}))
+	return s
+}
+
+func (s *topicServer) wsURL() string {
+	return "ws" + strings.TrimPrefix(s.URL, "http")
+}
+
+// readCommand reads the next command, failing the test if it isn't the expected one
+func readCommand(t *testing.T, conn *websocket.Conn, name string) map[string]interface{} {
+	_, msg, err := conn.ReadMessage()
+	if err != nil {
+		t.Error(err)
+		return nil
+	}
+	cmd := map[string]interface{}{}
+	if err := json.Unmarshal(msg, &cmd); err != nil {
+		t.Error(err)
+		return nil
+	}
+	if cmd["command"] != name {
+		t.Errorf("expected command %s, got %v", name, cmd["command"])
+	}
+	return cmd
+}
+
+func writeJSON(t *testing.T, conn *websocket.Conn, v interface{}) {
+	data, _ := json.Marshal(v)
+	if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSubscriber_ResumesFromOffset(t *testing.T) {
+	topicOffsets := make(chan string, 2)
+
+	ts := newTopicServer(t, func(conn *websocket.Conn, n int) {
+		cmd := readCommand(t, conn, "add-tenants-v1")
+		if cmd["clientSecret"] != "secret" || fmt.Sprint(cmd["accessTokens"]) != "[token]" {
+			t.Errorf("unexpected credentials %v", cmd)
+		}
+		writeJSON(t, conn, map[string]interface{}{"type": "command-response", "result": "ok", "response": "add-tenants-v1", "tenantIds": map[string]int64{"token": 42}})
+
+		cmd = readCommand(t, conn, "add-topics-v1")
+		topics := cmd["topics"].([]interface{})
+		offset, _ := topics[0].(map[string]interface{})["offset"].(string)
+		topicOffsets <- offset
+		writeJSON(t, conn, map[string]interface{}{"type": "command-response", "result": "ok", "response": "add-topics-v1"})
+
+		readCommand(t, conn, "subscribe-v1")
+		writeJSON(t, conn, map[string]interface{}{"type": "command-response", "result": "ok", "response": "subscribe-v1"})
+
+		if n == 1 {
+			writeJSON(t, conn, map[string]interface{}{"topic": "invoices", "offset": "1", "type": "invoice-created-v1", "tenantId": 42, "entityId": "1001"})
+			writeJSON(t, conn, map[string]interface{}{"topic": "invoices", "offset": "2", "type": "invoice-updated-v1", "tenantId": 42, "entityId": 1001})
+			// drop the connection without a close frame
+			return
+		}
+		writeJSON(t, conn, map[string]interface{}{"topic": "invoices", "offset": "3", "type": "invoice-bookkeep-v1", "tenantId": 42, "entityId": "1001"})
+		conn.ReadMessage()
+	})
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var events []TopicEvent
+	store := NewMemoryOffsetStore()
+	s := NewSubscriber(NewClient(WithAuthOpts("token", "secret")), store, func(ctx context.Context, ev TopicEvent) error {
+		events = append(events, ev)
+		if len(events) == 3 {
+			cancel()
+		}
+		return nil
+	})
+	s.URL = ts.wsURL()
+	s.MinBackoff = time.Millisecond
+	s.MaxBackoff = time.Millisecond
+
+	if err := s.Run(ctx, TopicInvoices); err != context.Canceled {
+		t.Fatal("expected canceled, got", err)
+	}
+
+	if first, second := <-topicOffsets, <-topicOffsets; first != "" || second != "2" {
+		t.Fatalf("expected to resume from offset 2, got %q then %q", first, second)
+	}
+	if len(events) != 3 {
+		t.Fatal("expected 3 events, got", len(events))
+	}
+	if events[1].EntityID != "1001" || events[1].TenantID != 42 || events[1].Action() != EventUpdated {
+		t.Fatalf("unexpected event %+v", events[1])
+	}
+	if events[2].Action() != "bookkeep" {
+		t.Fatal("unexpected action", events[2].Action())
+	}
+	if s.Tenants()["token"] != 42 {
+		t.Fatal("unexpected tenants", s.Tenants())
+	}
+	if offset, _ := store.LoadOffset(ctx, TopicInvoices); offset != "3" {
+		t.Fatal("unexpected stored offset", offset)
+	}
+}
+
+func TestSubscriber_InvalidTokens(t *testing.T) {
+	ts := newTopicServer(t, func(conn *websocket.Conn, n int) {
+		readCommand(t, conn, "add-tenants-v1")
+		writeJSON(t, conn, map[string]interface{}{"type": "command-response", "result": "ok", "response": "add-tenants-v1", "invalidTenants": []string{"token"}})
+		conn.ReadMessage()
+	})
+	defer ts.Close()
+
+	s := NewSubscriber(NewClient(WithAuthOpts("token", "secret")), NewMemoryOffsetStore(), func(ctx context.Context, ev TopicEvent) error {
+		return nil
+	})
+	s.URL = ts.wsURL()
+
+	err := s.Run(context.Background(), TopicOrders)
+	if !IsUnauthorized(err) {
+		t.Fatal("expected unauthorized, got", err)
+	}
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.conns != 1 {
+		t.Fatal("expected no reconnect, got connections:", ts.conns)
+	}
+}
+
+func TestSubscriber_HandlerErrorStops(t *testing.T) {
+	ts := newTopicServer(t, func(conn *websocket.Conn, n int) {
+		for _, name := range []string{"add-tenants-v1", "add-topics-v1", "subscribe-v1"} {
+			readCommand(t, conn, name)
+			writeJSON(t, conn, map[string]interface{}{"type": "command-response", "result": "ok", "response": name})
+		}
+		writeJSON(t, conn, map[string]interface{}{"topic": "orders", "offset": "7", "type": "order-created-v1", "entityId": "5"})
+		conn.ReadMessage()
+	})
+	defer ts.Close()
+
+	handlerErr := fmt.Errorf("database down")
+	store := NewMemoryOffsetStore()
+	s := NewSubscriber(NewClient(WithAuthOpts("token", "secret")), store, func(ctx context.Context, ev TopicEvent) error {
+		return handlerErr
+	})
+	s.URL = ts.wsURL()
+
+	if err := s.Run(context.Background(), TopicOrders); err != handlerErr {
+		t.Fatal("expected handler error, got", err)
+	}
+	if offset, _ := store.LoadOffset(context.Background(), TopicOrders); offset != "" {
+		t.Fatal("offset should not be saved, got", offset)
+	}
+}
+
+func TestSubscriber_Dialer(t *testing.T) {
+	ts := newUnstartedTopicServer(t, func(conn *websocket.Conn, n int) {
+		for _, name := range []string{"add-tenants-v1", "add-topics-v1", "subscribe-v1"} {
+			readCommand(t, conn, name)
+			writeJSON(t, conn, map[string]interface{}{"type": "command-response", "result": "ok", "response": name})
+		}
+		writeJSON(t, conn, map[string]interface{}{"topic": "orders", "offset": "1", "type": "order-created-v1", "entityId": "5"})
+		conn.ReadMessage()
+	})
+	ts.StartTLS()
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var proxied bool
+	s := NewSubscriber(NewClient(WithAuthOpts("token", "secret")), NewMemoryOffsetStore(), func(ctx context.Context, ev TopicEvent) error {
+		cancel()
+		return nil
+	})
+	s.URL = ts.wsURL()
+	s.Dialer = &websocket.Dialer{
+		TLSClientConfig: ts.Client().Transport.(*http.Transport).TLSClientConfig,
+		Proxy: func(r *http.Request) (*url.URL, error) {
+			proxied = true
+			return nil, nil
+		},
+	}
+
+	if err := s.Run(ctx, TopicOrders); err != context.Canceled {
+		t.Fatal("expected canceled, got", err)
+	}
+	if !proxied {
+		t.Fatal("expected the dialer's proxy func to be used")
+	}
+}
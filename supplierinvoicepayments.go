@@ -0,0 +1,173 @@
+package fortnox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// SupplierInvoicePayment data type
+type SupplierInvoicePayment struct {
+	URL                   string            `json:"@url"`
+	Amount                Floatish          `json:"Amount"`
+	AmountCurrency        Floatish          `json:"AmountCurrency"`
+	Booked                bool              `json:"Booked"`
+	Currency              string            `json:"Currency"`
+	CurrencyRate          Floatish          `json:"CurrencyRate"`
+	CurrencyUnit          Floatish          `json:"CurrencyUnit"`
+	InvoiceDueDate        Date              `json:"InvoiceDueDate"`
+	InvoiceNumber         Intish            `json:"InvoiceNumber"`
+	InvoiceOCR            string            `json:"InvoiceOCR"`
+	InvoiceTotal          Floatish          `json:"InvoiceTotal"`
+	InvoiceSupplierName   string            `json:"InvoiceSupplierName"`
+	InvoiceSupplierNumber string            `json:"InvoiceSupplierNumber"`
+	ModeOfPayment         string            `json:"ModeOfPayment"`
+	ModeOfPaymentAccount  Intish            `json:"ModeOfPaymentAccount"`
+	Number                Intish            `json:"Number"`
+	PaymentDate           Date              `json:"PaymentDate"`
+	Source                string            `json:"Source"`
+	VoucherNumber         Intish            `json:"VoucherNumber"`
+	VoucherSeries         string            `json:"VoucherSeries"`
+	VoucherYear           Intish            `json:"VoucherYear"`
+	WriteOffs             []PaymentWriteOff `json:"WriteOffs"`
+}
+
+// CreateSupplierInvoicePayment payload for registering supplier invoice payments. InvoiceNumber is the given number of the supplier invoice.
+// For payments in foreign currency set AmountCurrency and CurrencyRate, the exchange difference is booked with WriteOffs
+type CreateSupplierInvoicePayment struct {
+	Amount               *float64                 `json:"Amount,omitempty"`
+	AmountCurrency       *float64                 `json:"AmountCurrency,omitempty"`
+	CurrencyRate         *float64                 `json:"CurrencyRate,omitempty"`
+	CurrencyUnit         *float64                 `json:"CurrencyUnit,omitempty"`
+	InvoiceNumber        *int                     `json:"InvoiceNumber,omitempty"`
+	ModeOfPayment        *string                  `json:"ModeOfPayment,omitempty"`
+	ModeOfPaymentAccount *int                     `json:"ModeOfPaymentAccount,omitempty"`
+	PaymentDate          *string                  `json:"PaymentDate,omitempty"`
+	WriteOffs            []*CreatePaymentWriteOff `json:"WriteOffs,omitempty"`
+}
+
+// UpdateSupplierInvoicePayment payload for updating supplier invoice payments. Only unbooked payments can be updated
+type UpdateSupplierInvoicePayment CreateSupplierInvoicePayment
+
+// SupplierInvoicePaymentQueryParams when searching supplier invoice payments
+type SupplierInvoicePaymentQueryParams struct {
+	InvoiceNumber int
+	LastModified  time.Time
+	Page          int
+	Limit         int
+	Offset        int
+	Extra         map[string][]string
+}
+
+func (p SupplierInvoicePaymentQueryParams) toValues() url.Values {
+
+	ret := make(url.Values)
+
+	if p.InvoiceNumber > 0 {
+		ret["invoicenumber"] = []string{fmt.Sprintf("%d", p.InvoiceNumber)}
+	}
+	if !p.LastModified.IsZero() {
+		ret["lastmodified"] = []string{p.LastModified.Format(TimeFormat)}
+	}
+	if p.Limit > 0 {
+		ret["limit"] = []string{fmt.Sprintf("%d", p.Limit)}
+	}
+	if p.Offset > 0 {
+		ret["offset"] = []string{fmt.Sprintf("%d", p.Offset)}
+	}
+	if p.Page > 0 {
+		ret["page"] = []string{fmt.Sprintf("%d", p.Page)}
+	}
+	for k, vs := range p.Extra {
+		ret[k] = vs
+	}
+	return ret
+}
+
+// ListSupplierInvoicePaymentsResp Response when listing supplier invoice payments
+type ListSupplierInvoicePaymentsResp struct {
+	SupplierInvoicePayments []*SupplierInvoicePayment `json:"SupplierInvoicePayments"`
+	MetaInformation         *MetaInformation          `json:"MetaInformation"`
+}
+
+// ListSupplierInvoicePayments lists supplier invoice payments
+func (c *Client) ListSupplierInvoicePayments(ctx context.Context, p *SupplierInvoicePaymentQueryParams) (*ListSupplierInvoicePaymentsResp, error) {
+	resp := &ListSupplierInvoicePaymentsResp{}
+
+	var vals url.Values
+	if p != nil {
+		vals = p.toValues()
+	}
+
+	err := c.request(ctx, "GET", "supplierinvoicepayments", nil, vals, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SupplierInvoicePaymentResp Response for single supplier invoice payment
+type SupplierInvoicePaymentResp struct {
+	SupplierInvoicePayment SupplierInvoicePayment `json:"SupplierInvoicePayment"`
+}
+
+// GetSupplierInvoicePayment gets one supplier invoice payment by number
+func (c *Client) GetSupplierInvoicePayment(ctx context.Context, number int) (*SupplierInvoicePayment, error) {
+
+	resp := &SupplierInvoicePaymentResp{}
+
+	err := c.request(ctx, "GET", "supplierinvoicepayments/"+strconv.Itoa(number), nil, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.SupplierInvoicePayment, nil
+}
+
+// CreateSupplierInvoicePayment registers a payment of a supplier invoice
+func (c *Client) CreateSupplierInvoicePayment(ctx context.Context, payment *CreateSupplierInvoicePayment) (*SupplierInvoicePayment, error) {
+	resp := &SupplierInvoicePaymentResp{}
+	err := c.request(ctx, "POST", "supplierinvoicepayments/", &struct {
+		SupplierInvoicePayment *CreateSupplierInvoicePayment `json:"SupplierInvoicePayment"`
+	}{
+		SupplierInvoicePayment: payment,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.SupplierInvoicePayment, nil
+}
+
+// UpdateSupplierInvoicePayment updates a supplier invoice payment
+func (c *Client) UpdateSupplierInvoicePayment(ctx context.Context, number int, payment *UpdateSupplierInvoicePayment) (*SupplierInvoicePayment, error) {
+	resp := &SupplierInvoicePaymentResp{}
+	err := c.request(ctx, "PUT", "supplierinvoicepayments/"+strconv.Itoa(number), &struct {
+		SupplierInvoicePayment *UpdateSupplierInvoicePayment `json:"SupplierInvoicePayment"`
+	}{
+		SupplierInvoicePayment: payment,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.SupplierInvoicePayment, nil
+}
+
+// DeleteSupplierInvoicePayment deletes an unbooked supplier invoice payment
+func (c *Client) DeleteSupplierInvoicePayment(ctx context.Context, number int) error {
+	return c.deleteResource(ctx, "supplierinvoicepayments/"+strconv.Itoa(number))
+}
+
+// BookkeepSupplierInvoicePayment bookkeeps a supplier invoice payment
+func (c *Client) BookkeepSupplierInvoicePayment(ctx context.Context, number int) (*SupplierInvoicePayment, error) {
+	resp := &SupplierInvoicePaymentResp{}
+	err := c.action(ctx, "PUT", fmt.Sprintf("supplierinvoicepayments/%d/bookkeep", number), resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.SupplierInvoicePayment, nil
+}
@@ -0,0 +1,47 @@
+package fortnox
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestClient_SupplierInvoicePayments(t *testing.T) {
+	api := newStubAPI(t, map[string]string{
+		"GET supplierinvoicepayments":            `{"SupplierInvoicePayments":[{"Number":"3","InvoiceNumber":"12","Amount":"1250.00","Booked":false}],"MetaInformation":{"@CurrentPage":1,"@TotalPages":1,"@TotalResources":1}}`,
+		"GET supplierinvoicepayments/3":          `{"SupplierInvoicePayment":{"Number":"3","InvoiceNumber":"12","Amount":"1250.00"}}`,
+		"PUT supplierinvoicepayments/3":          `{"SupplierInvoicePayment":{"Number":"3","InvoiceNumber":"12","Amount":"1200.00"}}`,
+		"PUT supplierinvoicepayments/3/bookkeep": `{"SupplierInvoicePayment":{"Number":"3","Booked":true}}`,
+	})
+	defer api.Close()
+	c := api.client()
+	ctx := context.Background()
+
+	list, err := c.ListSupplierInvoicePayments(ctx, &SupplierInvoicePaymentQueryParams{InvoiceNumber: 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.SupplierInvoicePayments) != 1 || list.SupplierInvoicePayments[0].Amount != 1250 {
+		t.Fatalf("unexpected payments %+v", list.SupplierInvoicePayments)
+	}
+	if q := api.received("GET supplierinvoicepayments")[0].Query; q.Get("invoicenumber") != "12" {
+		t.Fatal("unexpected query", q)
+	}
+
+	if p, err := c.GetSupplierInvoicePayment(ctx, 3); err != nil || p.InvoiceNumber != 12 {
+		t.Fatal("unexpected payment", p, err)
+	}
+	amount := 1200.0
+	if p, err := c.UpdateSupplierInvoicePayment(ctx, 3, &UpdateSupplierInvoicePayment{Amount: &amount}); err != nil || p.Amount != 1200 {
+		t.Fatal("unexpected payment", p, err)
+	}
+
+	// bookkeeping may have happened before the server failed, so it isn't retried
+	api.fail("PUT supplierinvoicepayments/3/bookkeep", http.StatusInternalServerError)
+	if _, err := c.BookkeepSupplierInvoicePayment(ctx, 3); !IsServerError(err) {
+		t.Fatal("expected server error, got", err)
+	}
+	if n := len(api.received("PUT supplierinvoicepayments/3/bookkeep")); n != 1 {
+		t.Fatal("expected 1 request, got", n)
+	}
+}
@@ -0,0 +1,302 @@
+package fortnox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// SupplierInvoiceShort data type
+type SupplierInvoiceShort struct {
+	URL                   string   `json:"@url"`
+	Balance               Floatish `json:"Balance"`
+	Booked                bool     `json:"Booked"`
+	Cancelled             bool     `json:"Cancelled"`
+	CostCenter            string   `json:"CostCenter"`
+	Currency              string   `json:"Currency"`
+	CurrencyRate          Floatish `json:"CurrencyRate"`
+	CurrencyUnit          float64  `json:"CurrencyUnit"`
+	DueDate               Date     `json:"DueDate"`
+	ExternalInvoiceNumber string   `json:"ExternalInvoiceNumber"`
+	ExternalInvoiceSeries string   `json:"ExternalInvoiceSeries"`
+	GivenNumber           Intish   `json:"GivenNumber"`
+	InvoiceDate           Date     `json:"InvoiceDate"`
+	InvoiceNumber         string   `json:"InvoiceNumber"`
+	Project               string   `json:"Project"`
+	SupplierName          string   `json:"SupplierName"`
+	SupplierNumber        string   `json:"SupplierNumber"`
+	Total                 Floatish `json:"Total"`
+}
+
+// SupplierInvoiceRow data type
+type SupplierInvoiceRow struct {
+	Account                int      `json:"Account"`
+	AccountDescription     string   `json:"AccountDescription"`
+	ArticleNumber          string   `json:"ArticleNumber"`
+	Code                   string   `json:"Code"`
+	CostCenter             string   `json:"CostCenter"`
+	Credit                 Floatish `json:"Credit"`
+	CreditCurrency         Floatish `json:"CreditCurrency"`
+	Debit                  Floatish `json:"Debit"`
+	DebitCurrency          Floatish `json:"DebitCurrency"`
+	ItemDescription        string   `json:"ItemDescription"`
+	Price                  Floatish `json:"Price"`
+	Project                string   `json:"Project"`
+	Quantity               Floatish `json:"Quantity"`
+	Total                  Floatish `json:"Total"`
+	TransactionInformation string   `json:"TransactionInformation"`
+	Unit                   string   `json:"Unit"`
+}
+
+// CreateSupplierInvoiceRow payload for supplier invoice rows. Pointers since most fields are not required.
+type CreateSupplierInvoiceRow struct {
+	Account                *int      `json:"Account,omitempty"`
+	ArticleNumber          *string   `json:"ArticleNumber,omitempty"`
+	Code                   *string   `json:"Code,omitempty"`
+	CostCenter             *string   `json:"CostCenter,omitempty"`
+	Credit                 *float64  `json:"Credit,omitempty"`
+	CreditCurrency         *float64  `json:"CreditCurrency,omitempty"`
+	Debit                  *float64  `json:"Debit,omitempty"`
+	DebitCurrency          *float64  `json:"DebitCurrency,omitempty"`
+	ItemDescription        *string   `json:"ItemDescription,omitempty"`
+	Price                  *float64  `json:"Price,omitempty"`
+	Project                *string   `json:"Project,omitempty"`
+	Quantity               *Floatish `json:"Quantity,omitempty"`
+	Total                  *float64  `json:"Total,omitempty"`
+	TransactionInformation *string   `json:"TransactionInformation,omitempty"`
+	Unit                   *string   `json:"Unit,omitempty"`
+}
+
+// CreateSupplierInvoice payload for creating supplier invoices
+type CreateSupplierInvoice struct {
+	AdministrationFee     *float64                    `json:"AdministrationFee,omitempty"`
+	Comments              *string                     `json:"Comments,omitempty"`
+	CostCenter            *string                     `json:"CostCenter,omitempty"`
+	Credit                *bool                       `json:"Credit,omitempty"`
+	Currency              *string                     `json:"Currency,omitempty"`
+	CurrencyRate          *float64                    `json:"CurrencyRate,omitempty"`
+	CurrencyUnit          *float64                    `json:"CurrencyUnit,omitempty"`
+	DisablePaymentFile    *bool                       `json:"DisablePaymentFile,omitempty"`
+	DueDate               *string                     `json:"DueDate,omitempty"`
+	ExternalInvoiceNumber *string                     `json:"ExternalInvoiceNumber,omitempty"`
+	ExternalInvoiceSeries *string                     `json:"ExternalInvoiceSeries,omitempty"`
+	Freight               *float64                    `json:"Freight,omitempty"`
+	InvoiceDate           *string                     `json:"InvoiceDate,omitempty"`
+	InvoiceNumber         *string                     `json:"InvoiceNumber,omitempty"`
+	OCR                   *string                     `json:"OCR,omitempty"`
+	OurReference          *string                     `json:"OurReference,omitempty"`
+	PaymentPending        *bool                       `json:"PaymentPending,omitempty"`
+	Project               *string                     `json:"Project,omitempty"`
+	RoundOffValue         *float64                    `json:"RoundOffValue,omitempty"`
+	SalesType             *string                     `json:"SalesType,omitempty"`
+	SupplierInvoiceRows   []*CreateSupplierInvoiceRow `json:"SupplierInvoiceRows,omitempty"`
+	SupplierNumber        *string                     `json:"SupplierNumber,omitempty"`
+	Total                 *float64                    `json:"Total,omitempty"`
+	VAT                   *float64                    `json:"VAT,omitempty"`
+	VATType               *string                     `json:"VATType,omitempty"`
+	YourReference         *string                     `json:"YourReference,omitempty"`
+}
+
+// UpdateSupplierInvoice payload for updating supplier invoices
+type UpdateSupplierInvoice CreateSupplierInvoice
+
+// SupplierInvoiceFull data type
+type SupplierInvoiceFull struct {
+	URL                   string               `json:"@url"`
+	AccountingMethod      string               `json:"AccountingMethod"`
+	AdministrationFee     Floatish             `json:"AdministrationFee"`
+	Balance               Floatish             `json:"Balance"`
+	Booked                bool                 `json:"Booked"`
+	Cancelled             bool                 `json:"Cancelled"`
+	Comments              string               `json:"Comments"`
+	CostCenter            string               `json:"CostCenter"`
+	Credit                bool                 `json:"Credit"`
+	CreditReference       Intish               `json:"CreditReference"`
+	Currency              string               `json:"Currency"`
+	CurrencyRate          Floatish             `json:"CurrencyRate"`
+	CurrencyUnit          float64              `json:"CurrencyUnit"`
+	DisablePaymentFile    bool                 `json:"DisablePaymentFile"`
+	DueDate               Date                 `json:"DueDate"`
+	ExternalInvoiceNumber string               `json:"ExternalInvoiceNumber"`
+	ExternalInvoiceSeries string               `json:"ExternalInvoiceSeries"`
+	Freight               Floatish             `json:"Freight"`
+	GivenNumber           Intish               `json:"GivenNumber"`
+	InvoiceDate           Date                 `json:"InvoiceDate"`
+	InvoiceNumber         string               `json:"InvoiceNumber"`
+	OCR                   string               `json:"OCR"`
+	OurReference          string               `json:"OurReference"`
+	PaymentPending        bool                 `json:"PaymentPending"`
+	Project               string               `json:"Project"`
+	RoundOffValue         Floatish             `json:"RoundOffValue"`
+	SalesType             string               `json:"SalesType"`
+	SupplierInvoiceRows   []SupplierInvoiceRow `json:"SupplierInvoiceRows"`
+	SupplierName          string               `json:"SupplierName"`
+	SupplierNumber        string               `json:"SupplierNumber"`
+	Total                 Floatish             `json:"Total"`
+	VAT                   Floatish             `json:"VAT"`
+	VATType               string               `json:"VATType"`
+	VoucherNumber         Intish               `json:"VoucherNumber"`
+	VoucherSeries         string               `json:"VoucherSeries"`
+	VoucherYear           Intish               `json:"VoucherYear"`
+	YourReference         string               `json:"YourReference"`
+}
+
+// SupplierInvoiceQueryParams when searching supplier invoices.
+// Filter is one of cancelled, fullypaid, unpaid, unpaidoverdue, unbooked, pendingpayment or authorizepending
+type SupplierInvoiceQueryParams struct {
+	Filter            string
+	LastModified      time.Time
+	FinancialYear     int
+	FinancialYearDate string
+	FromDate          string
+	ToDate            string
+	Page              int
+	Limit             int
+	Offset            int
+	Extra             map[string][]string
+}
+
+func (p SupplierInvoiceQueryParams) toValues() url.Values {
+
+	ret := make(url.Values)
+
+	if len(p.Filter) > 0 {
+		ret["filter"] = []string{p.Filter}
+	}
+	if !p.LastModified.IsZero() {
+		ret["lastmodified"] = []string{p.LastModified.Format(TimeFormat)}
+	}
+	if p.FinancialYear > 0 {
+		ret["financialyear"] = []string{fmt.Sprintf("%d", p.FinancialYear)}
+	}
+	if len(p.FinancialYearDate) > 0 {
+		ret["financialyeardate"] = []string{p.FinancialYearDate}
+	}
+	if len(p.FromDate) > 0 {
+		ret["fromdate"] = []string{p.FromDate}
+	}
+	if len(p.ToDate) > 0 {
+		ret["todate"] = []string{p.ToDate}
+	}
+	if p.Limit > 0 {
+		ret["limit"] = []string{fmt.Sprintf("%d", p.Limit)}
+	}
+	if p.Offset > 0 {
+		ret["offset"] = []string{fmt.Sprintf("%d", p.Offset)}
+	}
+	if p.Page > 0 {
+		ret["page"] = []string{fmt.Sprintf("%d", p.Page)}
+	}
+	for k, vs := range p.Extra {
+		ret[k] = vs
+	}
+	return ret
+}
+
+// ListSupplierInvoicesResp Response when listing supplier invoices
+type ListSupplierInvoicesResp struct {
+	SupplierInvoices []*SupplierInvoiceShort `json:"SupplierInvoices"`
+	MetaInformation  *MetaInformation        `json:"MetaInformation"`
+}
+
+// ListSupplierInvoices lists supplier invoices
+func (c *Client) ListSupplierInvoices(ctx context.Context, p *SupplierInvoiceQueryParams) (*ListSupplierInvoicesResp, error) {
+	resp := &ListSupplierInvoicesResp{}
+
+	var vals url.Values
+	if p != nil {
+		vals = p.toValues()
+	}
+
+	err := c.request(ctx, "GET", "supplierinvoices", nil, vals, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SupplierInvoiceResp Response for single supplier invoice
+type SupplierInvoiceResp struct {
+	SupplierInvoice SupplierInvoiceFull `json:"SupplierInvoice"`
+}
+
+// GetSupplierInvoice gets one supplier invoice by its given number
+func (c *Client) GetSupplierInvoice(ctx context.Context, givenNumber int) (*SupplierInvoiceFull, error) {
+
+	resp := &SupplierInvoiceResp{}
+
+	err := c.request(ctx, "GET", fmt.Sprintf("supplierinvoices/%d", givenNumber), nil, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.SupplierInvoice, nil
+}
+
+// CreateSupplierInvoice creates a supplier invoice
+func (c *Client) CreateSupplierInvoice(ctx context.Context, invoice *CreateSupplierInvoice) (*SupplierInvoiceFull, error) {
+	resp := &SupplierInvoiceResp{}
+	err := c.request(ctx, "POST", "supplierinvoices/", &struct {
+		SupplierInvoice *CreateSupplierInvoice `json:"SupplierInvoice"`
+	}{
+		SupplierInvoice: invoice,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.SupplierInvoice, nil
+}
+
+// UpdateSupplierInvoice updates a supplier invoice
+func (c *Client) UpdateSupplierInvoice(ctx context.Context, givenNumber int, invoice *UpdateSupplierInvoice) (*SupplierInvoiceFull, error) {
+
+	resp := &SupplierInvoiceResp{}
+	err := c.request(ctx, "PUT", fmt.Sprintf("supplierinvoices/%d", givenNumber), &struct {
+		SupplierInvoice *UpdateSupplierInvoice `json:"SupplierInvoice"`
+	}{
+		SupplierInvoice: invoice,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.SupplierInvoice, nil
+}
+
+func (c *Client) supplierInvoiceAction(ctx context.Context, givenNumber int, action string) (*SupplierInvoiceFull, error) {
+
+	resp := &SupplierInvoiceResp{}
+	err := c.action(ctx, "PUT", fmt.Sprintf("supplierinvoices/%d/%s", givenNumber, action), resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.SupplierInvoice, nil
+}
+
+// BookkeepSupplierInvoice bookkeeps a supplier invoice
+func (c *Client) BookkeepSupplierInvoice(ctx context.Context, givenNumber int) (*SupplierInvoiceFull, error) {
+	return c.supplierInvoiceAction(ctx, givenNumber, "bookkeep")
+}
+
+// CancelSupplierInvoice cancels a supplier invoice
+func (c *Client) CancelSupplierInvoice(ctx context.Context, givenNumber int) (*SupplierInvoiceFull, error) {
+	return c.supplierInvoiceAction(ctx, givenNumber, "cancel")
+}
+
+// CreditSupplierInvoice credits a supplier invoice
+func (c *Client) CreditSupplierInvoice(ctx context.Context, givenNumber int) (*SupplierInvoiceFull, error) {
+	return c.supplierInvoiceAction(ctx, givenNumber, "credit")
+}
+
+// ApprovePaymentSupplierInvoice approves a supplier invoice for payment
+func (c *Client) ApprovePaymentSupplierInvoice(ctx context.Context, givenNumber int) (*SupplierInvoiceFull, error) {
+	return c.supplierInvoiceAction(ctx, givenNumber, "approvalpayment")
+}
+
+// ApproveBookkeepSupplierInvoice approves a supplier invoice for bookkeeping
+func (c *Client) ApproveBookkeepSupplierInvoice(ctx context.Context, givenNumber int) (*SupplierInvoiceFull, error) {
+	return c.supplierInvoiceAction(ctx, givenNumber, "approvalbookkeep")
+}
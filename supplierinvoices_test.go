@@ -0,0 +1,54 @@
+package fortnox
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestClient_SupplierInvoices(t *testing.T) {
+	api := newStubAPI(t, map[string]string{
+		"GET supplierinvoices":                    `{"SupplierInvoices":[{"GivenNumber":"12","SupplierNumber":"5","DueDate":"2020-04-30","Total":"1250.00","Booked":false}],"MetaInformation":{"@CurrentPage":1,"@TotalPages":1,"@TotalResources":1}}`,
+		"POST supplierinvoices/":                  `{"SupplierInvoice":{"GivenNumber":"12","SupplierNumber":"5","Total":"1250.00"}}`,
+		"GET supplierinvoices/12":                 `{"SupplierInvoice":{"GivenNumber":"12","SupplierNumber":"5","Total":"1250.00"}}`,
+		"PUT supplierinvoices/12/approvalpayment": `{"SupplierInvoice":{"GivenNumber":"12","SupplierNumber":"5","Total":"1250.00"}}`,
+		"PUT supplierinvoices/12/bookkeep":        `{"SupplierInvoice":{"GivenNumber":"12","SupplierNumber":"5","Booked":true}}`,
+	})
+	defer api.Close()
+	c := api.client()
+	ctx := context.Background()
+
+	list, err := c.ListSupplierInvoices(ctx, &SupplierInvoiceQueryParams{Filter: "unbooked"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.SupplierInvoices) != 1 || list.SupplierInvoices[0].GivenNumber != 12 || list.SupplierInvoices[0].Total != 1250 {
+		t.Fatalf("unexpected supplier invoices %+v", list.SupplierInvoices)
+	}
+	if q := api.received("GET supplierinvoices")[0].Query; q.Get("filter") != "unbooked" {
+		t.Fatal("unexpected query", q)
+	}
+
+	supplier := "5"
+	if _, err := c.CreateSupplierInvoice(ctx, &CreateSupplierInvoice{SupplierNumber: &supplier}); err != nil {
+		t.Fatal(err)
+	}
+	if body := api.received("POST supplierinvoices/")[0].Body; body != `{"SupplierInvoice":{"SupplierNumber":"5"}}`+"\n" {
+		t.Fatal("unexpected body", body)
+	}
+	if inv, err := c.GetSupplierInvoice(ctx, 12); err != nil || inv.SupplierNumber != "5" {
+		t.Fatal("unexpected supplier invoice", inv, err)
+	}
+	if _, err := c.ApprovePaymentSupplierInvoice(ctx, 12); err != nil {
+		t.Fatal(err)
+	}
+
+	// actions aren't retried, bookkeeping may have happened before the server failed
+	api.fail("PUT supplierinvoices/12/bookkeep", http.StatusInternalServerError)
+	if _, err := c.BookkeepSupplierInvoice(ctx, 12); !IsServerError(err) {
+		t.Fatal("expected server error, got", err)
+	}
+	if n := len(api.received("PUT supplierinvoices/12/bookkeep")); n != 1 {
+		t.Fatal("expected 1 request, got", n)
+	}
+}
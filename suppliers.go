@@ -0,0 +1,232 @@
+package fortnox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// A Supplier is the payload in the responses from the supplier endpoint
+type Supplier struct {
+	URL                 string    `json:"@url"`
+	Active              bool      `json:"Active"`
+	Address1            string    `json:"Address1"`
+	Address2            string    `json:"Address2"`
+	Bank                string    `json:"Bank"`
+	BankAccountNumber   string    `json:"BankAccountNumber"`
+	BG                  string    `json:"BG"`
+	BIC                 string    `json:"BIC"`
+	BranchCode          string    `json:"BranchCode"`
+	City                string    `json:"City"`
+	ClearingNumber      string    `json:"ClearingNumber"`
+	Comments            string    `json:"Comments"`
+	CostCenter          string    `json:"CostCenter"`
+	Country             string    `json:"Country"`
+	CountryCode         string    `json:"CountryCode"`
+	Currency            string    `json:"Currency"`
+	DisablePaymentFile  bool      `json:"DisablePaymentFile"`
+	Email               string    `json:"Email"`
+	Fax                 string    `json:"Fax"`
+	IBAN                string    `json:"IBAN"`
+	Name                string    `json:"Name"`
+	OrganisationNumber  string    `json:"OrganisationNumber"`
+	OurCustomerNumber   string    `json:"OurCustomerNumber"`
+	OurReference        string    `json:"OurReference"`
+	PG                  string    `json:"PG"`
+	Phone1              string    `json:"Phone1"`
+	Phone2              string    `json:"Phone2"`
+	PreDefinedAccount   StringIsh `json:"PreDefinedAccount"`
+	Project             string    `json:"Project"`
+	SupplierNumber      string    `json:"SupplierNumber"`
+	TermsOfPayment      StringIsh `json:"TermsOfPayment"`
+	VATNumber           string    `json:"VATNumber"`
+	VATType             string    `json:"VATType"`
+	VisitingAddress     string    `json:"VisitingAddress"`
+	VisitingCity        string    `json:"VisitingCity"`
+	VisitingCountry     string    `json:"VisitingCountry"`
+	VisitingCountryCode string    `json:"VisitingCountryCode"`
+	VisitingZipCode     string    `json:"VisitingZipCode"`
+	WorkPlace           string    `json:"WorkPlace"`
+	WWW                 string    `json:"WWW"`
+	YourReference       string    `json:"YourReference"`
+	ZipCode             string    `json:"ZipCode"`
+}
+
+// A CreateSupplier is the payload when creating suppliers
+type CreateSupplier struct {
+	Active              *bool      `json:"Active,omitempty"`
+	Address1            *string    `json:"Address1,omitempty"`
+	Address2            *string    `json:"Address2,omitempty"`
+	Bank                *string    `json:"Bank,omitempty"`
+	BankAccountNumber   *string    `json:"BankAccountNumber,omitempty"`
+	BG                  *string    `json:"BG,omitempty"`
+	BIC                 *string    `json:"BIC,omitempty"`
+	BranchCode          *string    `json:"BranchCode,omitempty"`
+	City                *string    `json:"City,omitempty"`
+	ClearingNumber      *string    `json:"ClearingNumber,omitempty"`
+	Comments            *string    `json:"Comments,omitempty"`
+	CostCenter          *string    `json:"CostCenter,omitempty"`
+	CountryCode         *string    `json:"CountryCode,omitempty"`
+	Currency            *string    `json:"Currency,omitempty"`
+	DisablePaymentFile  *bool      `json:"DisablePaymentFile,omitempty"`
+	Email               *string    `json:"Email,omitempty"`
+	Fax                 *string    `json:"Fax,omitempty"`
+	IBAN                *string    `json:"IBAN,omitempty"`
+	Name                *string    `json:"Name,omitempty"`
+	OrganisationNumber  *string    `json:"OrganisationNumber,omitempty"`
+	OurCustomerNumber   *string    `json:"OurCustomerNumber,omitempty"`
+	OurReference        *string    `json:"OurReference,omitempty"`
+	PG                  *string    `json:"PG,omitempty"`
+	Phone1              *string    `json:"Phone1,omitempty"`
+	Phone2              *string    `json:"Phone2,omitempty"`
+	PreDefinedAccount   *StringIsh `json:"PreDefinedAccount,omitempty"`
+	Project             *string    `json:"Project,omitempty"`
+	SupplierNumber      *string    `json:"SupplierNumber,omitempty"`
+	TermsOfPayment      *StringIsh `json:"TermsOfPayment,omitempty"`
+	VATNumber           *string    `json:"VATNumber,omitempty"`
+	VATType             *string    `json:"VATType,omitempty"`
+	VisitingAddress     *string    `json:"VisitingAddress,omitempty"`
+	VisitingCity        *string    `json:"VisitingCity,omitempty"`
+	VisitingCountryCode *string    `json:"VisitingCountryCode,omitempty"`
+	VisitingZipCode     *string    `json:"VisitingZipCode,omitempty"`
+	WorkPlace           *string    `json:"WorkPlace,omitempty"`
+	WWW                 *string    `json:"WWW,omitempty"`
+	YourReference       *string    `json:"YourReference,omitempty"`
+	ZipCode             *string    `json:"ZipCode,omitempty"`
+}
+
+// UpdateSupplier data type
+type UpdateSupplier CreateSupplier
+
+// ListSuppliersResp is the response for ListSuppliers
+type ListSuppliersResp struct {
+	Suppliers       []*Supplier      `json:"Suppliers"`
+	MetaInformation *MetaInformation `json:"MetaInformation"`
+}
+
+// A SupplierQueryParams is used for querying suppliers using ListSuppliers
+type SupplierQueryParams struct {
+	City               string
+	Email              string
+	Name               string
+	OrganisationNumber string
+	Phone1             string
+	SupplierNumber     string
+	ZipCode            string
+	LastModified       time.Time
+	Page               int
+	Limit              int
+	Offset             int
+	Extra              map[string][]string
+}
+
+func (p *SupplierQueryParams) toValues() url.Values {
+	ret := make(url.Values)
+
+	if len(p.City) > 0 {
+		ret["city"] = []string{p.City}
+	}
+	if len(p.Email) > 0 {
+		ret["email"] = []string{p.Email}
+	}
+	if len(p.Name) > 0 {
+		ret["name"] = []string{p.Name}
+	}
+	if len(p.OrganisationNumber) > 0 {
+		ret["organisationnumber"] = []string{p.OrganisationNumber}
+	}
+	if len(p.Phone1) > 0 {
+		ret["phone1"] = []string{p.Phone1}
+	}
+	if len(p.SupplierNumber) > 0 {
+		ret["suppliernumber"] = []string{p.SupplierNumber}
+	}
+	if len(p.ZipCode) > 0 {
+		ret["zipcode"] = []string{p.ZipCode}
+	}
+	if !p.LastModified.IsZero() {
+		ret["lastmodified"] = []string{p.LastModified.Format(TimeFormat)}
+	}
+	if p.Limit > 0 {
+		ret["limit"] = []string{fmt.Sprintf("%d", p.Limit)}
+	}
+	if p.Offset > 0 {
+		ret["offset"] = []string{fmt.Sprintf("%d", p.Offset)}
+	}
+	if p.Page > 0 {
+		ret["page"] = []string{fmt.Sprintf("%d", p.Page)}
+	}
+	for k, vs := range p.Extra {
+		ret[k] = vs
+	}
+	return ret
+}
+
+// ListSuppliers lists or searches suppliers
+func (c *Client) ListSuppliers(ctx context.Context, p *SupplierQueryParams) (*ListSuppliersResp, error) {
+	resp := &ListSuppliersResp{}
+
+	var vals url.Values
+	if p != nil {
+		vals = p.toValues()
+	}
+	err := c.request(ctx, "GET", "suppliers", nil, vals, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SupplierResp Response for single supplier
+type SupplierResp struct {
+	Supplier Supplier `json:"Supplier"`
+}
+
+// GetSupplier gets one supplier
+func (c *Client) GetSupplier(ctx context.Context, supplierNum string) (*Supplier, error) {
+
+	resp := &SupplierResp{}
+
+	err := c.request(ctx, "GET", "suppliers/"+supplierNum, nil, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Supplier, nil
+}
+
+// CreateSupplier creates a supplier
+func (c *Client) CreateSupplier(ctx context.Context, supplier *CreateSupplier) (*Supplier, error) {
+	resp := &SupplierResp{}
+	err := c.request(ctx, "POST", "suppliers/", &struct {
+		Supplier *CreateSupplier `json:"Supplier"`
+	}{
+		Supplier: supplier,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Supplier, nil
+}
+
+// UpdateSupplier updates a supplier
+func (c *Client) UpdateSupplier(ctx context.Context, supplierNum string, supplier *UpdateSupplier) (*Supplier, error) {
+	resp := &SupplierResp{}
+	err := c.request(ctx, "PUT", "suppliers/"+supplierNum, &struct {
+		Supplier *UpdateSupplier `json:"Supplier"`
+	}{
+		Supplier: supplier,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Supplier, nil
+}
+
+// DeleteSupplier deletes one supplier
+func (c *Client) DeleteSupplier(ctx context.Context, supplierNum string) error {
+	return c.deleteResource(ctx, "suppliers/"+supplierNum)
+}
@@ -0,0 +1,55 @@
+package fortnox
+
+import (
+	"context"
+	"testing"
+)
+
+func TestClient_Suppliers(t *testing.T) {
+	api := newStubAPI(t, map[string]string{
+		"GET suppliers":      `{"Suppliers":[{"SupplierNumber":"5","Name":"Leverantören AB","City":"Malmö"}],"MetaInformation":{"@CurrentPage":1,"@TotalPages":1,"@TotalResources":1}}`,
+		"POST suppliers/":    `{"Supplier":{"SupplierNumber":"5","Name":"Leverantören AB"}}`,
+		"PUT suppliers/5":    `{"Supplier":{"SupplierNumber":"5","Name":"Leverantören i Malmö AB"}}`,
+		"GET suppliers/5":    `{"Supplier":{"SupplierNumber":"5","Name":"Leverantören i Malmö AB"}}`,
+		"DELETE suppliers/5": ``,
+	})
+	defer api.Close()
+	c := api.client()
+	ctx := context.Background()
+
+	name := "Leverantören AB"
+	s, err := c.CreateSupplier(ctx, &CreateSupplier{Name: &name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body := api.received("POST suppliers/")[0].Body; body != `{"Supplier":{"Name":"Leverantören AB"}}`+"\n" {
+		t.Fatal("unexpected body", body)
+	}
+
+	name2 := "Leverantören i Malmö AB"
+	s, err = c.UpdateSupplier(ctx, s.SupplierNumber, &UpdateSupplier{Name: &name2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Name != name2 {
+		t.Fatal("unexpected name", s.Name)
+	}
+	if s, err = c.GetSupplier(ctx, "5"); err != nil || s.Name != name2 {
+		t.Fatal("unexpected supplier", s, err)
+	}
+
+	list, err := c.ListSuppliers(ctx, &SupplierQueryParams{City: "Malmö", Page: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Suppliers) != 1 || list.Suppliers[0].City != "Malmö" {
+		t.Fatalf("unexpected suppliers %+v", list.Suppliers)
+	}
+	if q := api.received("GET suppliers")[0].Query; q.Get("city") != "Malmö" || q.Get("page") != "2" {
+		t.Fatal("unexpected query", q)
+	}
+
+	if err := c.DeleteSupplier(ctx, "5"); err != nil {
+		t.Fatal(err)
+	}
+}
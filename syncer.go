@@ -0,0 +1,387 @@
+package fortnox
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultSyncOverlap is how far before the checkpoint a sync starts, to cover clock skew and fortnox's minute precision
+const DefaultSyncOverlap = 5 * time.Minute
+
+var (
+	fortnoxLocationOnce sync.Once
+	fortnoxLocation     *time.Location
+)
+
+// fortnox interprets lastmodified in swedish time
+func toFortnoxTime(t time.Time) time.Time {
+	fortnoxLocationOnce.Do(func() {
+		fortnoxLocation, _ = time.LoadLocation("Europe/Stockholm")
+	})
+	if fortnoxLocation == nil {
+		return t
+	}
+	return t.In(fortnoxLocation)
+}
+
+// Checkpoint is the high-water mark of a synced resource
+type Checkpoint struct {
+	LastModified time.Time `json:"lastModified"`
+	// Seen holds fingerprints of the full records modified inside the overlap, to skip them when they come up again.
+	// Older records can't come up again unless they change, so they're left out
+	Seen map[string]string `json:"seen,omitempty"`
+}
+
+// CheckpointStore persists checkpoints per resource
+type CheckpointStore interface {
+	// LoadCheckpoint returns nil if the resource has never been synced
+	LoadCheckpoint(ctx context.Context, resource string) (*Checkpoint, error)
+	SaveCheckpoint(ctx context.Context, resource string, cp *Checkpoint) error
+}
+
+// MemoryCheckpointStore keeps checkpoints in memory
+type MemoryCheckpointStore struct {
+	mu          sync.Mutex
+	checkpoints map[string]Checkpoint
+}
+
+// NewMemoryCheckpointStore creates an empty memory store
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{checkpoints: map[string]Checkpoint{}}
+}
+
+// LoadCheckpoint loads a checkpoint
+func (s *MemoryCheckpointStore) LoadCheckpoint(ctx context.Context, resource string) (*Checkpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cp, ok := s.checkpoints[resource]
+	if !ok {
+		return nil, nil
+	}
+	return &cp, nil
+}
+
+// SaveCheckpoint saves a checkpoint
+func (s *MemoryCheckpointStore) SaveCheckpoint(ctx context.Context, resource string, cp *Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkpoints[resource] = *cp
+	return nil
+}
+
+// FileCheckpointStore keeps checkpoints as json files in a directory, one per resource
+type FileCheckpointStore struct {
+	Dir string
+}
+
+// NewFileCheckpointStore creates a file store
+func NewFileCheckpointStore(dir string) *FileCheckpointStore {
+	return &FileCheckpointStore{Dir: dir}
+}
+
+func (s *FileCheckpointStore) path(resource string) string {
+	return filepath.Join(s.Dir, resource+".json")
+}
+
+// LoadCheckpoint loads a checkpoint
+func (s *FileCheckpointStore) LoadCheckpoint(ctx context.Context, resource string) (*Checkpoint, error) {
+	data, err := ioutil.ReadFile(s.path(resource))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read checkpoint file")
+	}
+	cp := &Checkpoint{}
+	if err := json.Unmarshal(data, cp); err != nil {
+		return nil, errors.Wrap(err, "failed to decode checkpoint file")
+	}
+	return cp, nil
+}
+
+// SaveCheckpoint saves a checkpoint, replacing the file atomically
+func (s *FileCheckpointStore) SaveCheckpoint(ctx context.Context, resource string, cp *Checkpoint) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(s.Dir, resource+".json.tmp")
+	if err != nil {
+		return errors.Wrap(err, "failed to create checkpoint file")
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return errors.Wrap(err, "failed to write checkpoint file")
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return errors.Wrap(err, "failed to write checkpoint file")
+	}
+	return os.Rename(tmp.Name(), s.path(resource))
+}
+
+// ChangeEvent is emitted for every record created or changed since the last sync
+type ChangeEvent struct {
+	Resource string
+	ID       string
+	// Record is the list item, e.g. *OrderShort
+	Record interface{}
+}
+
+// ChangeHandler handles change events. Returning an error aborts the sync without advancing the checkpoint,
+// so events are delivered at least once.
+type ChangeHandler func(ctx context.Context, ev ChangeEvent) error
+
+// A SyncSource lists the records of a resource modified since a point in time
+type SyncSource interface {
+	// Resource is also the api path of the records, e.g. records of "orders" are fetched from orders/{id}
+	Resource() string
+	Modified(ctx context.Context, c *Client, since time.Time, fn func(id string, record interface{}) error) error
+}
+
+// OrderSyncSource syncs orders. Params can narrow the selection, LastModified and Page are overwritten
+type OrderSyncSource struct {
+	Params *OrderQueryParams
+}
+
+// Resource name
+func (s OrderSyncSource) Resource() string {
+	return "orders"
+}
+
+// Modified walks orders modified since
+func (s OrderSyncSource) Modified(ctx context.Context, c *Client, since time.Time, fn func(id string, record interface{}) error) error {
+	p := OrderQueryParams{}
+	if s.Params != nil {
+		p = *s.Params
+	}
+	p.LastModified = since
+	p.Page = 0
+
+	it := c.Orders(ctx, &p)
+	defer it.Close()
+	for it.Next() {
+		if err := fn(it.Order().DocumentNumber, it.Order()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// InvoiceSyncSource syncs invoices. Params can narrow the selection, LastModified and Page are overwritten
+type InvoiceSyncSource struct {
+	Params *InvoiceQueryParams
+}
+
+// Resource name
+func (s InvoiceSyncSource) Resource() string {
+	return "invoices"
+}
+
+// Modified walks invoices modified since
+func (s InvoiceSyncSource) Modified(ctx context.Context, c *Client, since time.Time, fn func(id string, record interface{}) error) error {
+	p := InvoiceQueryParams{}
+	if s.Params != nil {
+		p = *s.Params
+	}
+	p.LastModified = since
+	p.Page = 0
+
+	it := c.Invoices(ctx, &p)
+	defer it.Close()
+	for it.Next() {
+		if err := fn(strconv.Itoa(it.Invoice().DocumentNumber.Int()), it.Invoice()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// CustomerSyncSource syncs customers. Params can narrow the selection, LastModified and Page are overwritten
+type CustomerSyncSource struct {
+	Params *CustomerQueryParams
+}
+
+// Resource name
+func (s CustomerSyncSource) Resource() string {
+	return "customers"
+}
+
+// Modified walks customers modified since
+func (s CustomerSyncSource) Modified(ctx context.Context, c *Client, since time.Time, fn func(id string, record interface{}) error) error {
+	p := CustomerQueryParams{}
+	if s.Params != nil {
+		p = *s.Params
+	}
+	p.LastModified = since
+	p.Page = 0
+
+	it := c.Customers(ctx, &p)
+	defer it.Close()
+	for it.Next() {
+		if err := fn(it.Customer().CustomerNumber, it.Customer()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// ArticleSyncSource syncs articles. Params can narrow the selection, LastModified and Page are overwritten
+type ArticleSyncSource struct {
+	Params *ArticleQueryParams
+}
+
+// Resource name
+func (s ArticleSyncSource) Resource() string {
+	return "articles"
+}
+
+// Modified walks articles modified since
+func (s ArticleSyncSource) Modified(ctx context.Context, c *Client, since time.Time, fn func(id string, record interface{}) error) error {
+	p := ArticleQueryParams{}
+	if s.Params != nil {
+		p = *s.Params
+	}
+	p.LastModified = since
+	p.Page = 0
+
+	it := c.Articles(ctx, &p)
+	defer it.Close()
+	for it.Next() {
+		if err := fn(it.Article().ArticleNumber, it.Article()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// Syncer pages through everything modified since the last sync and emits change events
+type Syncer struct {
+	Client  *Client
+	Store   CheckpointStore
+	Handler ChangeHandler
+	// Overlap defaults to DefaultSyncOverlap
+	Overlap time.Duration
+	// Since is where to start when there is no checkpoint, zero syncs everything
+	Since time.Time
+}
+
+// NewSyncer creates a syncer
+func NewSyncer(c *Client, store CheckpointStore, handler ChangeHandler) *Syncer {
+	return &Syncer{
+		Client:  c,
+		Store:   store,
+		Handler: handler,
+		Overlap: DefaultSyncOverlap,
+	}
+}
+
+// Sync runs one pass over each source
+func (s *Syncer) Sync(ctx context.Context, sources ...SyncSource) error {
+	for _, src := range sources {
+		if err := s.syncSource(ctx, src); err != nil {
+			return errors.Wrap(err, "failed to sync "+src.Resource())
+		}
+	}
+	return nil
+}
+
+// Run syncs every interval until ctx is done or a sync fails
+func (s *Syncer) Run(ctx context.Context, interval time.Duration, sources ...SyncSource) error {
+	for {
+		if err := s.Sync(ctx, sources...); err != nil {
+			return err
+		}
+		if err := sleepCtx(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Syncer) syncSource(ctx context.Context, src SyncSource) error {
+	resource := src.Resource()
+
+	cp, err := s.Store.LoadCheckpoint(ctx, resource)
+	if err != nil {
+		return err
+	}
+	if cp == nil {
+		cp = &Checkpoint{LastModified: s.Since}
+	}
+
+	start := timeNow()
+	since := cp.LastModified
+	if !since.IsZero() {
+		since = toFortnoxTime(since.Add(-s.Overlap))
+	}
+
+	// records modified inside the next sync's overlap will come up again, fingerprint them to tell if they changed
+	recent := map[string]bool{}
+	err = src.Modified(ctx, s.Client, toFortnoxTime(start.Add(-s.Overlap)), func(id string, record interface{}) error {
+		recent[id] = true
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	seen := map[string]string{}
+	done := map[string]bool{}
+	err = src.Modified(ctx, s.Client, since, func(id string, record interface{}) error {
+		// records from the last sync's overlap come up again however long ago it ran, so check them too
+		if _, known := cp.Seen[id]; !recent[id] && !known {
+			// the same record can show up on two pages if it changes while we're paging, the next sync will pick it up
+			if done[id] {
+				return nil
+			}
+			done[id] = true
+			return s.Handler(ctx, ChangeEvent{Resource: resource, ID: id, Record: record})
+		}
+
+		// list items don't hold every field, so changes only show in the full record
+		fp, err := s.fingerprint(ctx, resource, id)
+		if err != nil {
+			return err
+		}
+		last, ok := seen[id]
+		if !ok {
+			last, ok = cp.Seen[id]
+		}
+		seen[id] = fp
+		if ok && last == fp {
+			return nil
+		}
+		return s.Handler(ctx, ChangeEvent{Resource: resource, ID: id, Record: record})
+	})
+	if err != nil {
+		return err
+	}
+
+	for id := range seen {
+		if !recent[id] {
+			delete(seen, id)
+		}
+	}
+	return s.Store.SaveCheckpoint(ctx, resource, &Checkpoint{LastModified: start, Seen: seen})
+}
+
+// fingerprint hashes the full record as fortnox returns it
+func (s *Syncer) fingerprint(ctx context.Context, resource, id string) (string, error) {
+	var raw json.RawMessage
+	if err := s.Client.request(ctx, "GET", resource+"/"+url.PathEscape(id), nil, nil, &raw); err != nil {
+		return "", err
+	}
+	sum := sha1.Sum(raw)
+	return hex.EncodeToString(sum[:]), nil
+}
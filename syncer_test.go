@@ -0,0 +1,294 @@
+package fortnox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/byrnedo/go-fortnox/fortnoxtest"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeOrder struct {
+	customer string
+	// remarks are only in the full order
+	remarks string
+	// modified defaults to now
+	modified time.Time
+}
+
+type fakeOrders struct {
+	mu     sync.Mutex
+	orders map[string]fakeOrder
+}
+
+func (f *fakeOrders) set(id string, o fakeOrder) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if o.modified.IsZero() {
+		o.modified = time.Now()
+	}
+	f.orders[id] = o
+}
+
+func (f *fakeOrders) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if id := strings.TrimPrefix(r.URL.Path, "/orders/"); id != r.URL.Path {
+		o := f.orders[id]
+		fmt.Fprintf(w, `{"Order":{"DocumentNumber":"%s","CustomerName":"%s","Remarks":"%s"}}`, id, o.customer, o.remarks)
+		return
+	}
+	var since time.Time
+	if v := r.URL.Query().Get("lastmodified"); v != "" {
+		since, _ = time.ParseInLocation(TimeFormat, v, toFortnoxTime(time.Now()).Location())
+	}
+	fmt.Fprint(w, `{"Orders":[`)
+	i := 0
+	for id, o := range f.orders {
+		if o.modified.Before(since) {
+			continue
+		}
+		if i > 0 {
+			fmt.Fprint(w, ",")
+		}
+		fmt.Fprintf(w, `{"DocumentNumber":"%s","CustomerName":"%s","OrderDate":"2018-01-01"}`, id, o.customer)
+		i++
+	}
+	fmt.Fprint(w, `],"MetaInformation":{"@CurrentPage":1,"@TotalPages":1,"@TotalResources":1}}`)
+}
+
+func TestSyncer_Sync(t *testing.T) {
+	orders := &fakeOrders{orders: map[string]fakeOrder{}}
+	orders.set("1", fakeOrder{customer: "a"})
+	orders.set("2", fakeOrder{customer: "b"})
+	ts := httptest.NewServer(orders)
+	defer ts.Close()
+
+	var events []ChangeEvent
+	var failWith error
+	handler := func(ctx context.Context, ev ChangeEvent) error {
+		if failWith != nil {
+			return failWith
+		}
+		events = append(events, ev)
+		return nil
+	}
+
+	store := NewMemoryCheckpointStore()
+	c := NewClient(WithURLOpts(ts.URL+"/"), WithRateLimitOpts(0, 0, 0))
+	s := NewSyncer(c, store, handler)
+	ctx := context.Background()
+
+	if err := s.Sync(ctx, OrderSyncSource{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 {
+		t.Fatal("expected 2 events, got", len(events))
+	}
+	cp, _ := store.LoadCheckpoint(ctx, "orders")
+	if cp == nil || time.Since(cp.LastModified) > time.Minute {
+		t.Fatalf("unexpected checkpoint %+v", cp)
+	}
+
+	// unchanged records in the overlap are skipped
+	events = nil
+	if err := s.Sync(ctx, OrderSyncSource{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 0 {
+		t.Fatal("expected no events, got", len(events))
+	}
+
+	// failed handler doesn't advance checkpoint
+	orders.set("2", fakeOrder{customer: "c"})
+	failWith = errors.New("oops")
+	if err := s.Sync(ctx, OrderSyncSource{}); err == nil {
+		t.Fatal("expected error")
+	}
+	failWith = nil
+	if err := s.Sync(ctx, OrderSyncSource{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].ID != "2" || events[0].Record.(*OrderShort).CustomerName != "c" {
+		t.Fatalf("unexpected events %+v", events)
+	}
+
+	// changes to fields missing from the list are noticed too
+	events = nil
+	orders.set("1", fakeOrder{customer: "a", remarks: "deliver on monday"})
+	if err := s.Sync(ctx, OrderSyncSource{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].ID != "1" {
+		t.Fatalf("unexpected events %+v", events)
+	}
+}
+
+func TestSyncer_ForgetsRecordsOutsideOverlap(t *testing.T) {
+	orders := &fakeOrders{orders: map[string]fakeOrder{}}
+	orders.set("1", fakeOrder{customer: "a", modified: time.Now().Add(-time.Hour)})
+	orders.set("2", fakeOrder{customer: "b"})
+	ts := httptest.NewServer(orders)
+	defer ts.Close()
+
+	var events []ChangeEvent
+	store := NewMemoryCheckpointStore()
+	c := NewClient(WithURLOpts(ts.URL+"/"), WithRateLimitOpts(0, 0, 0))
+	s := NewSyncer(c, store, func(ctx context.Context, ev ChangeEvent) error {
+		events = append(events, ev)
+		return nil
+	})
+	ctx := context.Background()
+
+	if err := s.Sync(ctx, OrderSyncSource{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 {
+		t.Fatal("expected 2 events, got", len(events))
+	}
+	cp, _ := store.LoadCheckpoint(ctx, "orders")
+	if _, ok := cp.Seen["1"]; ok || len(cp.Seen) != 1 {
+		t.Fatal("expected only the recent order to be remembered, got", cp.Seen)
+	}
+}
+
+func TestSyncer_Fortnoxtest(t *testing.T) {
+	srv := fortnoxtest.NewServer()
+	defer srv.Close()
+
+	now := time.Date(2020, 3, 10, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	srv.SetClock(clock)
+	defer func(orig func() time.Time) { timeNow = orig }(timeNow)
+	timeNow = clock
+
+	if _, err := srv.Add(fortnoxtest.Customers, map[string]interface{}{"CustomerNumber": "K1", "Name": "Kund"}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := srv.Add(fortnoxtest.Orders, map[string]interface{}{"CustomerNumber": "K1"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var events []string
+	c := NewClient(WithAuthOpts("token", "secret"), WithURLOpts(srv.BaseURL()), WithRateLimitOpts(0, 0, 0))
+	s := NewSyncer(c, NewMemoryCheckpointStore(), func(ctx context.Context, ev ChangeEvent) error {
+		events = append(events, ev.ID)
+		return nil
+	})
+	ctx := context.Background()
+	// fetched counts the full records read for fingerprinting
+	var fetched int
+	sync := func() {
+		t.Helper()
+		events = nil
+		before := len(srv.Requests())
+		if err := s.Sync(ctx, OrderSyncSource{}, CustomerSyncSource{}); err != nil {
+			t.Fatal(err)
+		}
+		fetched = 0
+		for _, r := range srv.Requests()[before:] {
+			if strings.Count(r.Path, "/") == 1 {
+				fetched++
+			}
+		}
+	}
+
+	now = now.Add(time.Hour)
+	sync()
+	if fmt.Sprint(events) != "[1 2 3 K1]" {
+		t.Fatal("expected every record on the first sync, got", events)
+	}
+
+	// only records modified since the last sync are listed
+	now = now.Add(time.Hour)
+	remarks := "deliver on monday"
+	if _, err := c.UpdateOrder(ctx, 2, &UpdateOrder{Remarks: &remarks}); err != nil {
+		t.Fatal(err)
+	}
+	sync()
+	if fmt.Sprint(events) != "[2]" || fetched != 1 {
+		t.Fatalf("expected only the changed order, got %v and %d full records", events, fetched)
+	}
+
+	// the change is still inside the overlap, but has been handled
+	now = now.Add(time.Minute)
+	sync()
+	if len(events) != 0 {
+		t.Fatal("expected no events, got", events)
+	}
+
+	now = now.Add(time.Minute)
+	if _, err := c.CancelOrder(ctx, 3); err != nil {
+		t.Fatal(err)
+	}
+	sync()
+	if fmt.Sprint(events) != "[3]" {
+		t.Fatal("expected the cancelled order, got", events)
+	}
+}
+
+func TestSyncer_GapLongerThanOverlap(t *testing.T) {
+	srv := fortnoxtest.NewServer()
+	defer srv.Close()
+
+	now := time.Date(2020, 3, 10, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	srv.SetClock(clock)
+	defer func(orig func() time.Time) { timeNow = orig }(timeNow)
+	timeNow = clock
+
+	if _, err := srv.Add(fortnoxtest.Customers, map[string]interface{}{"CustomerNumber": "K1", "Name": "Kund"}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := srv.Add(fortnoxtest.Orders, map[string]interface{}{"CustomerNumber": "K1"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var events []string
+	c := NewClient(WithAuthOpts("token", "secret"), WithURLOpts(srv.BaseURL()), WithRateLimitOpts(0, 0, 0))
+	s := NewSyncer(c, NewMemoryCheckpointStore(), func(ctx context.Context, ev ChangeEvent) error {
+		events = append(events, ev.ID)
+		return nil
+	})
+	ctx := context.Background()
+	sync := func() {
+		t.Helper()
+		events = nil
+		if err := s.Sync(ctx, OrderSyncSource{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now = now.Add(time.Hour)
+	sync()
+	remarks := "deliver on monday"
+	if _, err := c.UpdateOrder(ctx, 1, &UpdateOrder{Remarks: &remarks}); err != nil {
+		t.Fatal(err)
+	}
+	sync()
+	if fmt.Sprint(events) != "[1]" {
+		t.Fatal("expected the changed order, got", events)
+	}
+
+	// order 1 is still listed, since the checkpoint's overlap covers it, but it hasn't changed
+	now = now.Add(time.Hour)
+	sync()
+	if len(events) != 0 {
+		t.Fatal("expected no events, got", events)
+	}
+
+	now = now.Add(time.Hour)
+	sync()
+	if len(events) != 0 {
+		t.Fatal("expected no events, got", events)
+	}
+}
@@ -0,0 +1,127 @@
+package fortnox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// TermsOfDelivery data type
+type TermsOfDelivery struct {
+	URL                string `json:"@url"`
+	Code               string `json:"Code"`
+	Description        string `json:"Description"`
+	DescriptionEnglish string `json:"DescriptionEnglish"`
+}
+
+// CreateTermsOfDelivery payload for creating terms of delivery
+type CreateTermsOfDelivery struct {
+	Code               *string `json:"Code,omitempty"`
+	Description        *string `json:"Description,omitempty"`
+	DescriptionEnglish *string `json:"DescriptionEnglish,omitempty"`
+}
+
+// UpdateTermsOfDelivery payload for updating terms of delivery
+type UpdateTermsOfDelivery CreateTermsOfDelivery
+
+// TermsOfDeliveryQueryParams when listing terms of delivery
+type TermsOfDeliveryQueryParams struct {
+	Page   int
+	Limit  int
+	Offset int
+	Extra  map[string][]string
+}
+
+func (p TermsOfDeliveryQueryParams) toValues() url.Values {
+
+	ret := make(url.Values)
+
+	if p.Limit > 0 {
+		ret["limit"] = []string{fmt.Sprintf("%d", p.Limit)}
+	}
+	if p.Offset > 0 {
+		ret["offset"] = []string{fmt.Sprintf("%d", p.Offset)}
+	}
+	if p.Page > 0 {
+		ret["page"] = []string{fmt.Sprintf("%d", p.Page)}
+	}
+	for k, vs := range p.Extra {
+		ret[k] = vs
+	}
+	return ret
+}
+
+// ListTermsOfDeliveriesResp Response when listing terms of delivery
+type ListTermsOfDeliveriesResp struct {
+	TermsOfDeliveries []*TermsOfDelivery `json:"TermsOfDeliveries"`
+	MetaInformation   *MetaInformation   `json:"MetaInformation"`
+}
+
+// ListTermsOfDeliveries lists terms of delivery
+func (c *Client) ListTermsOfDeliveries(ctx context.Context, p *TermsOfDeliveryQueryParams) (*ListTermsOfDeliveriesResp, error) {
+	resp := &ListTermsOfDeliveriesResp{}
+
+	var vals url.Values
+	if p != nil {
+		vals = p.toValues()
+	}
+
+	err := c.request(ctx, "GET", "termsofdeliveries", nil, vals, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// TermsOfDeliveryResp Response for single terms of delivery
+type TermsOfDeliveryResp struct {
+	TermsOfDelivery TermsOfDelivery `json:"TermsOfDelivery"`
+}
+
+// GetTermsOfDelivery gets one terms of delivery by code
+func (c *Client) GetTermsOfDelivery(ctx context.Context, code string) (*TermsOfDelivery, error) {
+
+	resp := &TermsOfDeliveryResp{}
+
+	err := c.request(ctx, "GET", "termsofdeliveries/"+url.PathEscape(code), nil, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.TermsOfDelivery, nil
+}
+
+// CreateTermsOfDelivery creates terms of delivery
+func (c *Client) CreateTermsOfDelivery(ctx context.Context, termsOfDelivery *CreateTermsOfDelivery) (*TermsOfDelivery, error) {
+	resp := &TermsOfDeliveryResp{}
+	err := c.request(ctx, "POST", "termsofdeliveries/", &struct {
+		TermsOfDelivery *CreateTermsOfDelivery `json:"TermsOfDelivery"`
+	}{
+		TermsOfDelivery: termsOfDelivery,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.TermsOfDelivery, nil
+}
+
+// UpdateTermsOfDelivery updates terms of delivery
+func (c *Client) UpdateTermsOfDelivery(ctx context.Context, code string, termsOfDelivery *UpdateTermsOfDelivery) (*TermsOfDelivery, error) {
+	resp := &TermsOfDeliveryResp{}
+	err := c.request(ctx, "PUT", "termsofdeliveries/"+url.PathEscape(code), &struct {
+		TermsOfDelivery *UpdateTermsOfDelivery `json:"TermsOfDelivery"`
+	}{
+		TermsOfDelivery: termsOfDelivery,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.TermsOfDelivery, nil
+}
+
+// DeleteTermsOfDelivery deletes terms of delivery
+func (c *Client) DeleteTermsOfDelivery(ctx context.Context, code string) error {
+	return c.deleteResource(ctx, "termsofdeliveries/"+url.PathEscape(code))
+}
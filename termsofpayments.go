@@ -0,0 +1,129 @@
+package fortnox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// TermsOfPayment data type
+type TermsOfPayment struct {
+	URL                string `json:"@url"`
+	Code               string `json:"Code"`
+	Description        string `json:"Description"`
+	DescriptionEnglish string `json:"DescriptionEnglish"`
+	NumberOfDays       Intish `json:"NumberOfDays"`
+}
+
+// CreateTermsOfPayment payload for creating terms of payment
+type CreateTermsOfPayment struct {
+	Code               *string `json:"Code,omitempty"`
+	Description        *string `json:"Description,omitempty"`
+	DescriptionEnglish *string `json:"DescriptionEnglish,omitempty"`
+	NumberOfDays       *int    `json:"NumberOfDays,omitempty"`
+}
+
+// UpdateTermsOfPayment payload for updating terms of payment
+type UpdateTermsOfPayment CreateTermsOfPayment
+
+// TermsOfPaymentQueryParams when listing terms of payment
+type TermsOfPaymentQueryParams struct {
+	Page   int
+	Limit  int
+	Offset int
+	Extra  map[string][]string
+}
+
+func (p TermsOfPaymentQueryParams) toValues() url.Values {
+
+	ret := make(url.Values)
+
+	if p.Limit > 0 {
+		ret["limit"] = []string{fmt.Sprintf("%d", p.Limit)}
+	}
+	if p.Offset > 0 {
+		ret["offset"] = []string{fmt.Sprintf("%d", p.Offset)}
+	}
+	if p.Page > 0 {
+		ret["page"] = []string{fmt.Sprintf("%d", p.Page)}
+	}
+	for k, vs := range p.Extra {
+		ret[k] = vs
+	}
+	return ret
+}
+
+// ListTermsOfPaymentsResp Response when listing terms of payment
+type ListTermsOfPaymentsResp struct {
+	TermsOfPayments []*TermsOfPayment `json:"TermsOfPayments"`
+	MetaInformation *MetaInformation  `json:"MetaInformation"`
+}
+
+// ListTermsOfPayments lists terms of payment
+func (c *Client) ListTermsOfPayments(ctx context.Context, p *TermsOfPaymentQueryParams) (*ListTermsOfPaymentsResp, error) {
+	resp := &ListTermsOfPaymentsResp{}
+
+	var vals url.Values
+	if p != nil {
+		vals = p.toValues()
+	}
+
+	err := c.request(ctx, "GET", "termsofpayments", nil, vals, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// TermsOfPaymentResp Response for single terms of payment
+type TermsOfPaymentResp struct {
+	TermsOfPayment TermsOfPayment `json:"TermsOfPayment"`
+}
+
+// GetTermsOfPayment gets one terms of payment by code
+func (c *Client) GetTermsOfPayment(ctx context.Context, code string) (*TermsOfPayment, error) {
+
+	resp := &TermsOfPaymentResp{}
+
+	err := c.request(ctx, "GET", "termsofpayments/"+url.PathEscape(code), nil, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.TermsOfPayment, nil
+}
+
+// CreateTermsOfPayment creates terms of payment
+func (c *Client) CreateTermsOfPayment(ctx context.Context, termsOfPayment *CreateTermsOfPayment) (*TermsOfPayment, error) {
+	resp := &TermsOfPaymentResp{}
+	err := c.request(ctx, "POST", "termsofpayments/", &struct {
+		TermsOfPayment *CreateTermsOfPayment `json:"TermsOfPayment"`
+	}{
+		TermsOfPayment: termsOfPayment,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.TermsOfPayment, nil
+}
+
+// UpdateTermsOfPayment updates terms of payment
+func (c *Client) UpdateTermsOfPayment(ctx context.Context, code string, termsOfPayment *UpdateTermsOfPayment) (*TermsOfPayment, error) {
+	resp := &TermsOfPaymentResp{}
+	err := c.request(ctx, "PUT", "termsofpayments/"+url.PathEscape(code), &struct {
+		TermsOfPayment *UpdateTermsOfPayment `json:"TermsOfPayment"`
+	}{
+		TermsOfPayment: termsOfPayment,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.TermsOfPayment, nil
+}
+
+// DeleteTermsOfPayment deletes terms of payment
+func (c *Client) DeleteTermsOfPayment(ctx context.Context, code string) error {
+	return c.deleteResource(ctx, "termsofpayments/"+url.PathEscape(code))
+}
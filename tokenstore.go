@@ -0,0 +1,234 @@
+package fortnox
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// TokenStore persists OAuth2 tokens so that rotated tokens survive restarts and can be shared between clients.
+// The client loads the token before each request and holds the lock while refreshing, so only one client refreshes.
+type TokenStore interface {
+	// Load returns the stored token, or nil if nothing has been saved yet
+	Load(ctx context.Context) (*Token, error)
+	// Save stores the token, replacing any previous one
+	Save(ctx context.Context, token *Token) error
+	// Lock blocks until the store is held exclusively or ctx is done. Call unlock to release it.
+	Lock(ctx context.Context) (unlock func(), err error)
+}
+
+// WithTokenStore helper for persisting and sharing OAuth2 tokens
+func WithTokenStore(store TokenStore) OptionsFunc {
+	return func(o *ClientOptions) {
+		o.TokenStore = store
+	}
+}
+
+// MemoryTokenStore keeps the token in memory, for sharing between clients in the same process
+type MemoryTokenStore struct {
+	mu    sync.Mutex
+	token *Token
+	lock  chan struct{}
+}
+
+// NewMemoryTokenStore creates a memory store, optionally seeded with a token
+func NewMemoryTokenStore(token *Token) *MemoryTokenStore {
+	s := &MemoryTokenStore{lock: make(chan struct{}, 1)}
+	if token != nil {
+		t := *token
+		s.token = &t
+	}
+	return s
+}
+
+// Load the token
+func (s *MemoryTokenStore) Load(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token == nil {
+		return nil, nil
+	}
+	t := *s.token
+	return &t, nil
+}
+
+// Save the token
+func (s *MemoryTokenStore) Save(ctx context.Context, token *Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := *token
+	s.token = &t
+	return nil
+}
+
+// Lock the store
+func (s *MemoryTokenStore) Lock(ctx context.Context) (func(), error) {
+	select {
+	case s.lock <- struct{}{}:
+		return func() { <-s.lock }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// FileTokenStore keeps the token in a json file, for sharing between processes on the same host.
+// Locking uses a sibling ".lock" file.
+type FileTokenStore struct {
+	Path string
+	// StaleLockAge is how old a lock file can get before it is assumed abandoned. Defaults to a minute.
+	StaleLockAge time.Duration
+	// PollInterval is how often to retry a held lock. Defaults to 50ms.
+	PollInterval time.Duration
+}
+
+// NewFileTokenStore creates a file store
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{
+		Path:         path,
+		StaleLockAge: time.Minute,
+		PollInterval: 50 * time.Millisecond,
+	}
+}
+
+// Load the token
+func (s *FileTokenStore) Load(ctx context.Context) (*Token, error) {
+	data, err := ioutil.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read token file")
+	}
+	token := &Token{}
+	if err := json.Unmarshal(data, token); err != nil {
+		return nil, errors.Wrap(err, "failed to decode token file")
+	}
+	return token, nil
+}
+
+// Save the token. The file is replaced atomically so readers never see a partial write.
+func (s *FileTokenStore) Save(ctx context.Context, token *Token) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(s.Path), filepath.Base(s.Path)+".tmp")
+	if err != nil {
+		return errors.Wrap(err, "failed to create token file")
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return errors.Wrap(err, "failed to write token file")
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return errors.Wrap(err, "failed to write token file")
+	}
+	return os.Rename(tmp.Name(), s.Path)
+}
+
+// Lock the store. The lock file holds a random owner id, so a holder whose lock was taken over as stale doesn't
+// release the new holder's lock
+func (s *FileTokenStore) Lock(ctx context.Context) (func(), error) {
+	lockPath := s.Path + ".lock"
+
+	staleAge := s.StaleLockAge
+	if staleAge <= 0 {
+		staleAge = time.Minute
+	}
+	poll := s.PollInterval
+	if poll <= 0 {
+		poll = 50 * time.Millisecond
+	}
+
+	ownerBytes := make([]byte, 16)
+	if _, err := rand.Read(ownerBytes); err != nil {
+		return nil, errors.Wrap(err, "failed to create lock owner")
+	}
+	owner := hex.EncodeToString(ownerBytes)
+
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			_, err = f.WriteString(owner)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				os.Remove(lockPath)
+				return nil, errors.Wrap(err, "failed to write lock file")
+			}
+			return func() {
+				removeLockIf(lockPath, owner, poll, staleAge, func(info os.FileInfo, data []byte) bool {
+					return string(data) == owner
+				})
+			}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, errors.Wrap(err, "failed to create lock file")
+		}
+
+		// holder probably crashed
+		if info, err := os.Stat(lockPath); err == nil && time.Since(info.ModTime()) > staleAge {
+			removeLockIf(lockPath, owner, poll, staleAge, func(info os.FileInfo, data []byte) bool {
+				return time.Since(info.ModTime()) > staleAge
+			})
+			continue
+		}
+
+		select {
+		case <-time.After(poll):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// removeLockIf removes the lock file if it matches. The lock is moved aside and checked again before it is removed,
+// so a lock created by someone else in the meantime is never removed. Should that happen it is put back as soon as
+// the path is free again, and left aside rather than deleted if that takes longer than wait.
+func removeLockIf(lockPath, owner string, poll, wait time.Duration, match func(info os.FileInfo, data []byte) bool) {
+	if !lockMatches(lockPath, match) {
+		return
+	}
+
+	aside := lockPath + "." + owner
+	if err := os.Rename(lockPath, aside); err != nil {
+		return
+	}
+	if lockMatches(aside, match) {
+		os.Remove(aside)
+		return
+	}
+
+	// link fails rather than overwrite if the lock has been taken since
+	deadline := time.Now().Add(wait)
+	for {
+		err := os.Link(aside, lockPath)
+		if err == nil {
+			os.Remove(aside)
+			return
+		}
+		if !os.IsExist(err) || time.Now().After(deadline) {
+			return
+		}
+		time.Sleep(poll)
+	}
+}
+
+func lockMatches(path string, match func(info os.FileInfo, data []byte) bool) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	data, err := ioutil.ReadFile(path)
+	return err == nil && match(info, data)
+}
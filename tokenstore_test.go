@@ -0,0 +1,241 @@
+package fortnox
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFileTokenStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fortnox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	store := NewFileTokenStore(filepath.Join(dir, "token.json"))
+
+	token, err := store.Load(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != nil {
+		t.Fatal("expected no token")
+	}
+
+	if err := store.Save(ctx, &Token{AccessToken: "a", RefreshToken: "r"}); err != nil {
+		t.Fatal(err)
+	}
+	token, err = store.Load(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != "a" || token.RefreshToken != "r" {
+		t.Fatalf("unexpected token %+v", token)
+	}
+
+	unlock, err := store.Lock(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	if _, err := store.Lock(timeoutCtx); err != context.DeadlineExceeded {
+		t.Fatal("expected lock to time out, got", err)
+	}
+	unlock()
+	unlock, err = store.Lock(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlock()
+}
+
+func TestFileTokenStore_StaleLock(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fortnox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	store := NewFileTokenStore(filepath.Join(dir, "token.json"))
+	store.StaleLockAge = 50 * time.Millisecond
+	store.PollInterval = time.Millisecond
+
+	unlockStale, err := store.Lock(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	unlock, err := store.Lock(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the stale holder must not release the lock it lost
+	unlockStale()
+	if _, err := os.Stat(store.Path + ".lock"); err != nil {
+		t.Fatal("lock was released by its old holder:", err)
+	}
+
+	unlock()
+	if _, err := os.Stat(store.Path + ".lock"); !os.IsNotExist(err) {
+		t.Fatal("expected lock to be released, got", err)
+	}
+	files, _ := ioutil.ReadDir(dir)
+	if len(files) != 0 {
+		t.Fatal("expected no leftover lock files, got", len(files))
+	}
+}
+
+func TestRemoveLockIf(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fortnox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	lockPath := filepath.Join(dir, "token.json.lock")
+	ownedBy := func(owner string) func(os.FileInfo, []byte) bool {
+		return func(info os.FileInfo, data []byte) bool { return string(data) == owner }
+	}
+	if err := ioutil.WriteFile(lockPath, []byte("other"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// someone else's lock isn't touched
+	removeLockIf(lockPath, "me", time.Millisecond, time.Millisecond, ownedBy("me"))
+	if data, err := ioutil.ReadFile(lockPath); err != nil || string(data) != "other" {
+		t.Fatal("expected the lock to be kept, got", string(data), err)
+	}
+
+	// nor is a lock taken over after it was checked
+	if err := ioutil.WriteFile(lockPath, []byte("me"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	removeLockIf(lockPath, "me", time.Millisecond, time.Millisecond, func(info os.FileInfo, data []byte) bool {
+		if string(data) == "me" {
+			os.Remove(lockPath)
+			ioutil.WriteFile(lockPath, []byte("new"), 0600)
+			return true
+		}
+		return false
+	})
+	if data, err := ioutil.ReadFile(lockPath); err != nil || string(data) != "new" {
+		t.Fatal("expected the new lock to be kept, got", string(data), err)
+	}
+
+	removeLockIf(lockPath, "me", time.Millisecond, time.Millisecond, ownedBy("new"))
+	files, _ := ioutil.ReadDir(dir)
+	if len(files) != 0 {
+		t.Fatal("expected the lock to be removed, got files:", len(files))
+	}
+}
+
+func TestClient_SharedTokenStoreRefreshesOnce(t *testing.T) {
+	var refreshes int32
+	ts := newTestTokenServer(t, &refreshes)
+	defer ts.Close()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != fmt.Sprintf("Bearer access%d", atomic.LoadInt32(&refreshes)) {
+			w.WriteHeader(401)
+			fmt.Fprint(w, `{"ErrorInformation":{"Error":1,"Message":"Invalid token","Code":2000311}}`)
+			return
+		}
+		fmt.Fprint(w, `{"Labels":[]}`)
+	}))
+	defer api.Close()
+
+	conf := &OAuthConfig{ClientID: "id", ClientSecret: "secret", TokenURL: ts.URL}
+	store := NewMemoryTokenStore(&Token{AccessToken: "access0", RefreshToken: "refresh0", Expiry: time.Now().Add(-time.Minute)})
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c := NewClient(WithOAuthOpts(conf, nil), WithTokenStore(store), WithURLOpts(api.URL+"/"), WithRateLimitOpts(0, 0, 0))
+			if _, err := c.ListLabels(context.Background()); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+
+	if n := atomic.LoadInt32(&refreshes); n != 1 {
+		t.Fatal("expected 1 refresh, got", n)
+	}
+	token, _ := store.Load(context.Background())
+	if token.AccessToken != "access1" {
+		t.Fatalf("unexpected stored token %+v", token)
+	}
+}
+
+// flakyTokenStore fails to save a number of times
+type flakyTokenStore struct {
+	*MemoryTokenStore
+	failures int32
+}
+
+func (s *flakyTokenStore) Save(ctx context.Context, token *Token) error {
+	if atomic.AddInt32(&s.failures, -1) >= 0 {
+		return fmt.Errorf("disk full")
+	}
+	return s.MemoryTokenStore.Save(ctx, token)
+}
+
+func TestClient_KeepsUnsavedToken(t *testing.T) {
+	var refreshes int32
+	ts := newTestTokenServer(t, &refreshes)
+	defer ts.Close()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"Labels":[]}`)
+	}))
+	defer api.Close()
+
+	conf := &OAuthConfig{ClientID: "id", ClientSecret: "secret", TokenURL: ts.URL}
+	store := &flakyTokenStore{
+		MemoryTokenStore: NewMemoryTokenStore(&Token{AccessToken: "access0", RefreshToken: "refresh0", Expiry: time.Now().Add(-time.Minute)}),
+		failures:         2,
+	}
+	c := NewClient(WithOAuthOpts(conf, nil), WithTokenStore(store), WithURLOpts(api.URL+"/"), WithRateLimitOpts(0, 0, 0))
+	ctx := context.Background()
+
+	if _, err := c.ListLabels(ctx); err == nil {
+		t.Fatal("expected the failed save to be returned")
+	}
+	if token := c.Token(); token == nil || token.RefreshToken != "refresh1" {
+		t.Fatalf("expected the client to keep the refreshed token, got %+v", token)
+	}
+	if _, err := c.ListLabels(ctx); err == nil {
+		t.Fatal("expected the failed save to be returned")
+	}
+
+	if _, err := c.ListLabels(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&refreshes); n != 1 {
+		t.Fatal("expected 1 refresh, got", n)
+	}
+	if token, _ := store.Load(ctx); token.RefreshToken != "refresh1" {
+		t.Fatalf("unexpected stored token %+v", token)
+	}
+}
@@ -0,0 +1,129 @@
+package fortnox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// Unit data type
+type Unit struct {
+	URL                string `json:"@url"`
+	Code               string `json:"Code"`
+	CodeEnglish        string `json:"CodeEnglish"`
+	Description        string `json:"Description"`
+	DescriptionEnglish string `json:"DescriptionEnglish"`
+}
+
+// CreateUnit payload for creating units
+type CreateUnit struct {
+	Code               *string `json:"Code,omitempty"`
+	CodeEnglish        *string `json:"CodeEnglish,omitempty"`
+	Description        *string `json:"Description,omitempty"`
+	DescriptionEnglish *string `json:"DescriptionEnglish,omitempty"`
+}
+
+// UpdateUnit payload for updating units
+type UpdateUnit CreateUnit
+
+// UnitQueryParams when listing units
+type UnitQueryParams struct {
+	Page   int
+	Limit  int
+	Offset int
+	Extra  map[string][]string
+}
+
+func (p UnitQueryParams) toValues() url.Values {
+
+	ret := make(url.Values)
+
+	if p.Limit > 0 {
+		ret["limit"] = []string{fmt.Sprintf("%d", p.Limit)}
+	}
+	if p.Offset > 0 {
+		ret["offset"] = []string{fmt.Sprintf("%d", p.Offset)}
+	}
+	if p.Page > 0 {
+		ret["page"] = []string{fmt.Sprintf("%d", p.Page)}
+	}
+	for k, vs := range p.Extra {
+		ret[k] = vs
+	}
+	return ret
+}
+
+// ListUnitsResp Response when listing units
+type ListUnitsResp struct {
+	Units           []*Unit          `json:"Units"`
+	MetaInformation *MetaInformation `json:"MetaInformation"`
+}
+
+// ListUnits lists units
+func (c *Client) ListUnits(ctx context.Context, p *UnitQueryParams) (*ListUnitsResp, error) {
+	resp := &ListUnitsResp{}
+
+	var vals url.Values
+	if p != nil {
+		vals = p.toValues()
+	}
+
+	err := c.request(ctx, "GET", "units", nil, vals, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UnitResp Response for single unit
+type UnitResp struct {
+	Unit Unit `json:"Unit"`
+}
+
+// GetUnit gets one unit by code
+func (c *Client) GetUnit(ctx context.Context, code string) (*Unit, error) {
+
+	resp := &UnitResp{}
+
+	err := c.request(ctx, "GET", "units/"+url.PathEscape(code), nil, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Unit, nil
+}
+
+// CreateUnit creates an unit
+func (c *Client) CreateUnit(ctx context.Context, unit *CreateUnit) (*Unit, error) {
+	resp := &UnitResp{}
+	err := c.request(ctx, "POST", "units/", &struct {
+		Unit *CreateUnit `json:"Unit"`
+	}{
+		Unit: unit,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Unit, nil
+}
+
+// UpdateUnit updates an unit
+func (c *Client) UpdateUnit(ctx context.Context, code string, unit *UpdateUnit) (*Unit, error) {
+	resp := &UnitResp{}
+	err := c.request(ctx, "PUT", "units/"+url.PathEscape(code), &struct {
+		Unit *UpdateUnit `json:"Unit"`
+	}{
+		Unit: unit,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Unit, nil
+}
+
+// DeleteUnit deletes one unit
+func (c *Client) DeleteUnit(ctx context.Context, code string) error {
+	return c.deleteResource(ctx, "units/"+url.PathEscape(code))
+}
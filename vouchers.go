@@ -0,0 +1,306 @@
+package fortnox
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"math"
+	"net/url"
+	"time"
+)
+
+// VoucherShort data type
+type VoucherShort struct {
+	URL             string `json:"@url"`
+	Comments        string `json:"Comments"`
+	Description     string `json:"Description"`
+	ReferenceNumber string `json:"ReferenceNumber"`
+	ReferenceType   string `json:"ReferenceType"`
+	TransactionDate Date   `json:"TransactionDate"`
+	VoucherNumber   Intish `json:"VoucherNumber"`
+	VoucherSeries   string `json:"VoucherSeries"`
+	Year            Intish `json:"Year"`
+	ApprovalState   Intish `json:"ApprovalState"`
+}
+
+// VoucherRow data type
+type VoucherRow struct {
+	Account                int      `json:"Account"`
+	CostCenter             string   `json:"CostCenter"`
+	Credit                 Floatish `json:"Credit"`
+	Debit                  Floatish `json:"Debit"`
+	Description            string   `json:"Description"`
+	Project                string   `json:"Project"`
+	Quantity               Floatish `json:"Quantity"`
+	Removed                bool     `json:"Removed"`
+	TransactionInformation string   `json:"TransactionInformation"`
+}
+
+// Voucher data type
+type Voucher struct {
+	URL             string       `json:"@url"`
+	Comments        string       `json:"Comments"`
+	CostCenter      string       `json:"CostCenter"`
+	Description     string       `json:"Description"`
+	Project         string       `json:"Project"`
+	ReferenceNumber string       `json:"ReferenceNumber"`
+	ReferenceType   string       `json:"ReferenceType"`
+	TransactionDate Date         `json:"TransactionDate"`
+	VoucherNumber   Intish       `json:"VoucherNumber"`
+	VoucherRows     []VoucherRow `json:"VoucherRows"`
+	VoucherSeries   string       `json:"VoucherSeries"`
+	Year            Intish       `json:"Year"`
+	ApprovalState   Intish       `json:"ApprovalState"`
+}
+
+// CreateVoucherRow payload for voucher rows
+type CreateVoucherRow struct {
+	Account                *int     `json:"Account,omitempty"`
+	CostCenter             *string  `json:"CostCenter,omitempty"`
+	Credit                 *float64 `json:"Credit,omitempty"`
+	Debit                  *float64 `json:"Debit,omitempty"`
+	Description            *string  `json:"Description,omitempty"`
+	Project                *string  `json:"Project,omitempty"`
+	Quantity               *float64 `json:"Quantity,omitempty"`
+	TransactionInformation *string  `json:"TransactionInformation,omitempty"`
+}
+
+// CreateVoucher payload for creating vouchers. Vouchers can't be updated once created
+type CreateVoucher struct {
+	Comments        *string             `json:"Comments,omitempty"`
+	CostCenter      *string             `json:"CostCenter,omitempty"`
+	Description     *string             `json:"Description,omitempty"`
+	Project         *string             `json:"Project,omitempty"`
+	ReferenceNumber *string             `json:"ReferenceNumber,omitempty"`
+	ReferenceType   *string             `json:"ReferenceType,omitempty"`
+	TransactionDate *string             `json:"TransactionDate,omitempty"`
+	VoucherRows     []*CreateVoucherRow `json:"VoucherRows,omitempty"`
+	VoucherSeries   *string             `json:"VoucherSeries,omitempty"`
+}
+
+// UnbalancedVoucherError is returned by CreateVoucher when debit and credit don't add up. It matches ErrValidation
+type UnbalancedVoucherError struct {
+	Debit  float64
+	Credit float64
+}
+
+// Error pretty print error
+func (e UnbalancedVoucherError) Error() string {
+	return fmt.Sprintf("voucher is unbalanced: debit %.2f, credit %.2f", e.Debit, e.Credit)
+}
+
+// Is makes the error match ErrValidation
+func (e UnbalancedVoucherError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// ErrVoucherNoRows is returned by CreateVoucher for a voucher without rows. It matches ErrValidation
+var ErrVoucherNoRows error = voucherNoRowsError{}
+
+type voucherNoRowsError struct{}
+
+func (voucherNoRowsError) Error() string { return "voucher has no rows" }
+
+func (voucherNoRowsError) Is(target error) bool { return target == ErrValidation }
+
+// Balance sums the debit and credit of all rows
+func (v *CreateVoucher) Balance() (debit float64, credit float64) {
+	for _, r := range v.VoucherRows {
+		if r == nil {
+			continue
+		}
+		if r.Debit != nil {
+			debit += *r.Debit
+		}
+		if r.Credit != nil {
+			credit += *r.Credit
+		}
+	}
+	return debit, credit
+}
+
+// Validate checks that the voucher has rows and that debit equals credit, to the öre
+func (v *CreateVoucher) Validate() error {
+	if len(v.VoucherRows) == 0 {
+		return ErrVoucherNoRows
+	}
+	debit, credit := v.Balance()
+	if math.Round(debit*100) != math.Round(credit*100) {
+		return UnbalancedVoucherError{Debit: debit, Credit: credit}
+	}
+	return nil
+}
+
+// VoucherQueryParams when searching vouchers
+type VoucherQueryParams struct {
+	// Series lists only vouchers in the series
+	Series            string
+	FinancialYear     int
+	FinancialYearDate string
+	FromDate          string
+	ToDate            string
+	LastModified      time.Time
+	Page              int
+	Limit             int
+	Offset            int
+	Extra             map[string][]string
+}
+
+func (p VoucherQueryParams) toValues() url.Values {
+
+	ret := make(url.Values)
+
+	if p.FinancialYear > 0 {
+		ret["financialyear"] = []string{fmt.Sprintf("%d", p.FinancialYear)}
+	}
+	if len(p.FinancialYearDate) > 0 {
+		ret["financialyeardate"] = []string{p.FinancialYearDate}
+	}
+	if len(p.FromDate) > 0 {
+		ret["fromdate"] = []string{p.FromDate}
+	}
+	if len(p.ToDate) > 0 {
+		ret["todate"] = []string{p.ToDate}
+	}
+	if !p.LastModified.IsZero() {
+		ret["lastmodified"] = []string{p.LastModified.Format(TimeFormat)}
+	}
+	if p.Limit > 0 {
+		ret["limit"] = []string{fmt.Sprintf("%d", p.Limit)}
+	}
+	if p.Offset > 0 {
+		ret["offset"] = []string{fmt.Sprintf("%d", p.Offset)}
+	}
+	if p.Page > 0 {
+		ret["page"] = []string{fmt.Sprintf("%d", p.Page)}
+	}
+	for k, vs := range p.Extra {
+		ret[k] = vs
+	}
+	return ret
+}
+
+// ListVouchersResp Response when listing vouchers
+type ListVouchersResp struct {
+	Vouchers        []*VoucherShort  `json:"Vouchers"`
+	MetaInformation *MetaInformation `json:"MetaInformation"`
+}
+
+// ListVouchers lists vouchers, in all series unless p.Series is set
+func (c *Client) ListVouchers(ctx context.Context, p *VoucherQueryParams) (*ListVouchersResp, error) {
+	resp := &ListVouchersResp{}
+
+	resource := "vouchers"
+	var vals url.Values
+	if p != nil {
+		vals = p.toValues()
+		if len(p.Series) > 0 {
+			resource = "vouchers/sublist/" + url.PathEscape(p.Series)
+		}
+	}
+
+	err := c.request(ctx, "GET", resource, nil, vals, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// VoucherResp Response for single voucher
+type VoucherResp struct {
+	Voucher Voucher `json:"Voucher"`
+}
+
+// GetVoucher gets one voucher by series and number. financialYear is the id of the financial year, 0 for the current one
+func (c *Client) GetVoucher(ctx context.Context, series string, number int, financialYear int) (*Voucher, error) {
+
+	resp := &VoucherResp{}
+
+	var vals url.Values
+	if financialYear > 0 {
+		vals = url.Values{"financialyear": {fmt.Sprintf("%d", financialYear)}}
+	}
+
+	err := c.request(ctx, "GET", fmt.Sprintf("vouchers/%s/%d", url.PathEscape(series), number), nil, vals, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Voucher, nil
+}
+
+// CreateVoucher creates a voucher. It fails without calling fortnox if the voucher has no rows or debit and credit differ
+func (c *Client) CreateVoucher(ctx context.Context, voucher *CreateVoucher) (*Voucher, error) {
+	if voucher == nil {
+		return nil, errors.Wrap(ErrValidation, "no voucher given")
+	}
+	if err := voucher.Validate(); err != nil {
+		return nil, err
+	}
+
+	resp := &VoucherResp{}
+	err := c.request(ctx, "POST", "vouchers/", &struct {
+		Voucher *CreateVoucher `json:"Voucher"`
+	}{
+		Voucher: voucher,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.Voucher, nil
+}
+
+// VoucherSeries data type
+type VoucherSeries struct {
+	URL      string `json:"@url"`
+	Approver *struct {
+		ID   Intish `json:"Id"`
+		Name string `json:"Name"`
+	} `json:"Approver"`
+	Code              string `json:"Code"`
+	Description       string `json:"Description"`
+	Manual            bool   `json:"Manual"`
+	NextVoucherNumber Intish `json:"NextVoucherNumber"`
+	Year              Intish `json:"Year"`
+}
+
+// ListVoucherSeriesResp Response when listing voucher series
+type ListVoucherSeriesResp struct {
+	VoucherSeriesCollection []*VoucherSeries `json:"VoucherSeriesCollection"`
+	MetaInformation         *MetaInformation `json:"MetaInformation"`
+}
+
+// ListVoucherSeries lists voucher series. financialYear is the id of the financial year, 0 for the current one
+func (c *Client) ListVoucherSeries(ctx context.Context, financialYear int) (*ListVoucherSeriesResp, error) {
+	resp := &ListVoucherSeriesResp{}
+
+	var vals url.Values
+	if financialYear > 0 {
+		vals = url.Values{"financialyear": {fmt.Sprintf("%d", financialYear)}}
+	}
+
+	err := c.request(ctx, "GET", "voucherseries", nil, vals, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// VoucherSeriesResp Response for single voucher series
+type VoucherSeriesResp struct {
+	VoucherSeries VoucherSeries `json:"VoucherSeries"`
+}
+
+// GetVoucherSeries gets one voucher series by code
+func (c *Client) GetVoucherSeries(ctx context.Context, code string) (*VoucherSeries, error) {
+
+	resp := &VoucherSeriesResp{}
+
+	err := c.request(ctx, "GET", "voucherseries/"+url.PathEscape(code), nil, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.VoucherSeries, nil
+}
@@ -0,0 +1,109 @@
+package fortnox
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateVoucher_Validate(t *testing.T) {
+	acc := func(i int) *int { return &i }
+	amount := func(f float64) *float64 { return &f }
+
+	balanced := &CreateVoucher{VoucherRows: []*CreateVoucherRow{
+		{Account: acc(7010), Debit: amount(0.1)},
+		{Account: acc(7010), Debit: amount(0.2)},
+		{Account: acc(2710), Credit: amount(0.3)},
+	}}
+	if err := balanced.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	unbalanced := &CreateVoucher{VoucherRows: []*CreateVoucherRow{
+		{Account: acc(7010), Debit: amount(100)},
+		{Account: acc(2710), Credit: amount(99.99)},
+	}}
+	err := unbalanced.Validate()
+	if !errors.Is(err, ErrValidation) {
+		t.Fatal("expected validation error, got", err)
+	}
+	if e, ok := err.(UnbalancedVoucherError); !ok || e.Debit != 100 || e.Credit != 99.99 {
+		t.Fatalf("unexpected error %#v", err)
+	}
+
+	if err := (&CreateVoucher{}).Validate(); err != ErrVoucherNoRows || !IsValidation(err) {
+		t.Fatal("expected no rows error, got", err)
+	}
+}
+
+func TestClient_CreateVoucherUnbalanced(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unbalanced voucher was sent")
+	}))
+	defer ts.Close()
+
+	c := NewClient(WithURLOpts(ts.URL+"/"), WithRateLimitOpts(0, 0, 0))
+	amount := 10.0
+	_, err := c.CreateVoucher(context.Background(), &CreateVoucher{VoucherRows: []*CreateVoucherRow{{Debit: &amount}}})
+	if !IsValidation(err) {
+		t.Fatal("expected validation error, got", err)
+	}
+	if _, err := c.CreateVoucher(context.Background(), nil); !IsValidation(err) {
+		t.Fatal("expected validation error for a nil voucher, got", err)
+	}
+}
+
+func TestClient_Vouchers(t *testing.T) {
+	api := newStubAPI(t, map[string]string{
+		"GET vouchers/sublist/A": `{"Vouchers":[{"VoucherSeries":"A","VoucherNumber":"3","Year":"2","TransactionDate":"2020-01-31"}],"MetaInformation":{"@CurrentPage":1,"@TotalPages":1,"@TotalResources":1}}`,
+		"GET vouchers/A/3":       `{"Voucher":{"VoucherSeries":"A","VoucherNumber":"3","Year":"2","VoucherRows":[{"Account":1930,"Debit":"100.00"},{"Account":3001,"Credit":100}]}}`,
+		"POST vouchers/":         `{"Voucher":{"VoucherSeries":"A","VoucherNumber":"4","Year":"2"}}`,
+		"GET voucherseries":      `{"VoucherSeriesCollection":[{"Code":"A","Description":"Redovisningsserie","NextVoucherNumber":"5"}],"MetaInformation":{"@CurrentPage":1,"@TotalPages":1,"@TotalResources":1}}`,
+	})
+	defer api.Close()
+	c := api.client()
+	ctx := context.Background()
+
+	list, err := c.ListVouchers(ctx, &VoucherQueryParams{Series: "A", FinancialYear: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Vouchers) != 1 || list.Vouchers[0].VoucherNumber != 3 {
+		t.Fatalf("unexpected vouchers %+v", list.Vouchers)
+	}
+	if q := api.received("GET vouchers/sublist/A")[0].Query; q.Get("financialyear") != "2" {
+		t.Fatal("unexpected query", q)
+	}
+
+	v, err := c.GetVoucher(ctx, "A", 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(v.VoucherRows) != 2 || v.VoucherRows[0].Debit != 100 || v.VoucherRows[1].Credit != 100 {
+		t.Fatalf("unexpected voucher rows %+v", v.VoucherRows)
+	}
+
+	acc := func(i int) *int { return &i }
+	amount := func(f float64) *float64 { return &f }
+	series := "A"
+	v, err = c.CreateVoucher(ctx, &CreateVoucher{VoucherSeries: &series, VoucherRows: []*CreateVoucherRow{
+		{Account: acc(1930), Debit: amount(100)},
+		{Account: acc(3001), Credit: amount(100)},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.VoucherNumber != 4 {
+		t.Fatal("unexpected voucher number", v.VoucherNumber)
+	}
+
+	seriesList, err := c.ListVoucherSeries(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(seriesList.VoucherSeriesCollection) != 1 || seriesList.VoucherSeriesCollection[0].NextVoucherNumber != 5 {
+		t.Fatalf("unexpected series %+v", seriesList.VoucherSeriesCollection)
+	}
+}
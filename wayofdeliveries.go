@@ -0,0 +1,127 @@
+package fortnox
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// WayOfDelivery data type
+type WayOfDelivery struct {
+	URL                string `json:"@url"`
+	Code               string `json:"Code"`
+	Description        string `json:"Description"`
+	DescriptionEnglish string `json:"DescriptionEnglish"`
+}
+
+// CreateWayOfDelivery payload for creating ways of delivery
+type CreateWayOfDelivery struct {
+	Code               *string `json:"Code,omitempty"`
+	Description        *string `json:"Description,omitempty"`
+	DescriptionEnglish *string `json:"DescriptionEnglish,omitempty"`
+}
+
+// UpdateWayOfDelivery payload for updating ways of delivery
+type UpdateWayOfDelivery CreateWayOfDelivery
+
+// WayOfDeliveryQueryParams when listing ways of delivery
+type WayOfDeliveryQueryParams struct {
+	Page   int
+	Limit  int
+	Offset int
+	Extra  map[string][]string
+}
+
+func (p WayOfDeliveryQueryParams) toValues() url.Values {
+
+	ret := make(url.Values)
+
+	if p.Limit > 0 {
+		ret["limit"] = []string{fmt.Sprintf("%d", p.Limit)}
+	}
+	if p.Offset > 0 {
+		ret["offset"] = []string{fmt.Sprintf("%d", p.Offset)}
+	}
+	if p.Page > 0 {
+		ret["page"] = []string{fmt.Sprintf("%d", p.Page)}
+	}
+	for k, vs := range p.Extra {
+		ret[k] = vs
+	}
+	return ret
+}
+
+// ListWayOfDeliveriesResp Response when listing ways of delivery
+type ListWayOfDeliveriesResp struct {
+	WayOfDeliveries []*WayOfDelivery `json:"WayOfDeliveries"`
+	MetaInformation *MetaInformation `json:"MetaInformation"`
+}
+
+// ListWayOfDeliveries lists ways of delivery
+func (c *Client) ListWayOfDeliveries(ctx context.Context, p *WayOfDeliveryQueryParams) (*ListWayOfDeliveriesResp, error) {
+	resp := &ListWayOfDeliveriesResp{}
+
+	var vals url.Values
+	if p != nil {
+		vals = p.toValues()
+	}
+
+	err := c.request(ctx, "GET", "wayofdeliveries", nil, vals, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// WayOfDeliveryResp Response for single way of delivery
+type WayOfDeliveryResp struct {
+	WayOfDelivery WayOfDelivery `json:"WayOfDelivery"`
+}
+
+// GetWayOfDelivery gets one way of delivery by code
+func (c *Client) GetWayOfDelivery(ctx context.Context, code string) (*WayOfDelivery, error) {
+
+	resp := &WayOfDeliveryResp{}
+
+	err := c.request(ctx, "GET", "wayofdeliveries/"+url.PathEscape(code), nil, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.WayOfDelivery, nil
+}
+
+// CreateWayOfDelivery creates a way of delivery
+func (c *Client) CreateWayOfDelivery(ctx context.Context, wayOfDelivery *CreateWayOfDelivery) (*WayOfDelivery, error) {
+	resp := &WayOfDeliveryResp{}
+	err := c.request(ctx, "POST", "wayofdeliveries/", &struct {
+		WayOfDelivery *CreateWayOfDelivery `json:"WayOfDelivery"`
+	}{
+		WayOfDelivery: wayOfDelivery,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.WayOfDelivery, nil
+}
+
+// UpdateWayOfDelivery updates a way of delivery
+func (c *Client) UpdateWayOfDelivery(ctx context.Context, code string, wayOfDelivery *UpdateWayOfDelivery) (*WayOfDelivery, error) {
+	resp := &WayOfDeliveryResp{}
+	err := c.request(ctx, "PUT", "wayofdeliveries/"+url.PathEscape(code), &struct {
+		WayOfDelivery *UpdateWayOfDelivery `json:"WayOfDelivery"`
+	}{
+		WayOfDelivery: wayOfDelivery,
+	}, nil, resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resp.WayOfDelivery, nil
+}
+
+// DeleteWayOfDelivery deletes one way of delivery
+func (c *Client) DeleteWayOfDelivery(ctx context.Context, code string) error {
+	return c.deleteResource(ctx, "wayofdeliveries/"+url.PathEscape(code))
+}